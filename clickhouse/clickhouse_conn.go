@@ -73,6 +73,35 @@ func GetConn(cfg *config.Config) (driver.Conn, error) {
 	return conn, err
 }
 
+// gridColumns Колонки таблиц свойств данных, добавленные после первой версии схемы
+var gridColumns = []string{
+	"missing_count Int32",
+	"reference_value Float32",
+	"binary_scale Int16",
+	"decimal_scale Int16",
+	"discipline UInt8",
+	"category UInt8",
+	"number UInt8",
+	"unit String",
+	"short_name String",
+	"standard_name String",
+	"legend Map(String, String)",
+	"window_start DateTime",
+	"window_end DateTime",
+	"statistical_process UInt8",
+	"step_type String",
+	"ensemble_type UInt8",
+	"ensemble_member UInt8",
+	"ensemble_size UInt8",
+	"ensemble_product String",
+	"interpolated Bool",
+	"value_min Nullable(Float64)",
+	"value_max Nullable(Float64)",
+	"value_mean Nullable(Float64)",
+	"value_std Nullable(Float64)",
+	"value_percentiles Map(String, Float64)",
+}
+
 // CheckTable Проверяет, существуют ли необходимые таблицы, и, если не существуют, создает их
 func CheckTable(cfg *config.Config) error {
 	// Полученеи соединения
@@ -137,7 +166,57 @@ func CheckTable(cfg *config.Config) error {
 	
 		surface_type String,
 
-		grid JSON
+		grid JSON,
+
+		missing_count Int32,
+
+		reference_value Float32,
+
+		binary_scale Int16,
+
+		decimal_scale Int16,
+
+		discipline UInt8,
+
+		category UInt8,
+
+		number UInt8,
+
+		unit String,
+
+		short_name String,
+
+		standard_name String,
+
+		legend Map(String, String),
+
+		window_start DateTime,
+
+		window_end DateTime,
+
+		statistical_process UInt8,
+
+		step_type String,
+
+		ensemble_type UInt8,
+
+		ensemble_member UInt8,
+
+		ensemble_size UInt8,
+
+		ensemble_product String,
+
+		interpolated Bool,
+
+		value_min Nullable(Float64),
+
+		value_max Nullable(Float64),
+
+		value_mean Nullable(Float64),
+
+		value_std Nullable(Float64),
+
+		value_percentiles Map(String, Float64)
 	)
 	ENGINE = MergeTree
 	ORDER BY (surface_value, parameter)
@@ -157,7 +236,57 @@ func CheckTable(cfg *config.Config) error {
 
 		surface_type String,
 
-		grid JSON
+		grid JSON,
+
+		missing_count Int32,
+
+		reference_value Float32,
+
+		binary_scale Int16,
+
+		decimal_scale Int16,
+
+		discipline UInt8,
+
+		category UInt8,
+
+		number UInt8,
+
+		unit String,
+
+		short_name String,
+
+		standard_name String,
+
+		legend Map(String, String),
+
+		window_start DateTime,
+
+		window_end DateTime,
+
+		statistical_process UInt8,
+
+		step_type String,
+
+		ensemble_type UInt8,
+
+		ensemble_member UInt8,
+
+		ensemble_size UInt8,
+
+		ensemble_product String,
+
+		interpolated Bool,
+
+		value_min Nullable(Float64),
+
+		value_max Nullable(Float64),
+
+		value_mean Nullable(Float64),
+
+		value_std Nullable(Float64),
+
+		value_percentiles Map(String, Float64)
 	)
 	ENGINE = MergeTree
 	ORDER BY (surface_value, parameter)
@@ -177,7 +306,57 @@ func CheckTable(cfg *config.Config) error {
 
 		surface_type String,
 
-		grid JSON
+		grid JSON,
+
+		missing_count Int32,
+
+		reference_value Float32,
+
+		binary_scale Int16,
+
+		decimal_scale Int16,
+
+		discipline UInt8,
+
+		category UInt8,
+
+		number UInt8,
+
+		unit String,
+
+		short_name String,
+
+		standard_name String,
+
+		legend Map(String, String),
+
+		window_start DateTime,
+
+		window_end DateTime,
+
+		statistical_process UInt8,
+
+		step_type String,
+
+		ensemble_type UInt8,
+
+		ensemble_member UInt8,
+
+		ensemble_size UInt8,
+
+		ensemble_product String,
+
+		interpolated Bool,
+
+		value_min Nullable(Float64),
+
+		value_max Nullable(Float64),
+
+		value_mean Nullable(Float64),
+
+		value_std Nullable(Float64),
+
+		value_percentiles Map(String, Float64)
 	)
 	ENGINE = MergeTree
 	ORDER BY (surface_value, parameter)
@@ -275,6 +454,37 @@ func CheckTable(cfg *config.Config) error {
 		return err
 	}
 
+	// Добавление колонок, появившихся после создания таблиц
+	for _, table := range []string{"grid", "grid_buff", "grid_prev"} {
+		for _, column := range gridColumns {
+			err = clickhouseConn.Exec(context.Background(), fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, column))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Тип значений в таблицах данных согласно VALUE_TYPE
+	valueType := "Array(Float64)"
+	if cfg.ValueType == "float32" {
+		valueType = "Array(Float32)"
+	}
+	for _, table := range []string{"grib_data", "grib_data_buff", "grib_data_prev"} {
+		var current string
+		err = clickhouseConn.QueryRow(context.Background(), "SELECT type FROM system.columns WHERE database = currentDatabase() AND table = $1 AND name = 'grib_data'", table).Scan(&current)
+		if err != nil {
+			return err
+		}
+		if current == valueType {
+			continue
+		}
+		config.Logger.Info("Изменение типа колонки grib_data таблицы ", table, " на ", valueType)
+		err = clickhouseConn.Exec(context.Background(), fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN grib_data %s", table, valueType))
+		if err != nil {
+			return err
+		}
+	}
+
 	defer clickhouseConn.Close()
 
 	return nil
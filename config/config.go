@@ -24,6 +24,34 @@ type Config struct {
 	SrcDir           string
 	SaveAs           string
 	CountFilePerTick string
+	DecodeWorkers    string
+	OnCorrupt        string
+	Validate         string
+	MaxMessageSize   string
+	MaxPoints        string
+	MaxGroups        string
+	MissingValue     string
+	RoundValues      string
+	ValueType        string
+	DataInt          string
+	TablesDir        string
+	Include          string
+	Exclude          string
+	EnsembleStats    string
+	Thresholds       string
+	Regrid           string
+	RegridMethod     string
+	Region           string
+	RotateWinds      string
+	Units            string
+	Derive           string
+	Profiles         string
+	ProfileLevels    string
+	ProfilePoints    string
+	ProfileDir       string
+	TimeStep         string
+	TimeParameters   string
+	Percentiles      string
 }
 
 // Создание логера, записывающего данные в файл
@@ -64,5 +92,33 @@ func New() *Config {
 		SrcDir:           getEnv("SOURCE_DIR", ""),
 		SaveAs:           getEnv("SAVE_AS", ""),
 		CountFilePerTick: getEnv("COUNT_FILE_PER_TICK", ""),
+		DecodeWorkers:    getEnv("DECODE_WORKERS", ""),
+		OnCorrupt:        getEnv("ON_CORRUPT_MESSAGE", "fail"),
+		Validate:         getEnv("VALIDATE", ""),
+		MaxMessageSize:   getEnv("MAX_MESSAGE_SIZE", ""),
+		MaxPoints:        getEnv("MAX_POINTS", ""),
+		MaxGroups:        getEnv("MAX_GROUPS", ""),
+		MissingValue:     getEnv("MISSING_VALUE", ""),
+		RoundValues:      getEnv("ROUND_VALUES", "false"),
+		ValueType:        getEnv("VALUE_TYPE", "float64"),
+		DataInt:          getEnv("DATA_INT", "packed"),
+		TablesDir:        getEnv("TABLES_DIR", ""),
+		Include:          getEnv("INCLUDE", ""),
+		Exclude:          getEnv("EXCLUDE", ""),
+		EnsembleStats:    getEnv("ENSEMBLE_STATS", ""),
+		Thresholds:       getEnv("ENSEMBLE_THRESHOLDS", ""),
+		Regrid:           getEnv("REGRID", ""),
+		RegridMethod:     getEnv("REGRID_METHOD", "bilinear"),
+		Region:           getEnv("REGION", ""),
+		RotateWinds:      getEnv("ROTATE_WINDS", "false"),
+		Units:            getEnv("UNITS", ""),
+		Derive:           getEnv("DERIVE", ""),
+		Profiles:         getEnv("PROFILES", ""),
+		ProfileLevels:    getEnv("PROFILE_LEVELS", ""),
+		ProfilePoints:    getEnv("PROFILE_POINTS", ""),
+		ProfileDir:       getEnv("PROFILE_DIR", ""),
+		TimeStep:         getEnv("TIME_STEP", ""),
+		TimeParameters:   getEnv("TIME_PARAMETERS", ""),
+		Percentiles:      getEnv("STAT_PERCENTILES", "5,25,50,75,95"),
 	}
 }
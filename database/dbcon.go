@@ -32,10 +32,40 @@ func Create() {
 	}
 
 	migrateGribData()
+	migrateValueType(cfg.ValueType)
 	migrateHash()
 
 }
 
+// gribDataColumns Колонки таблиц данных, добавленные после первой версии схемы
+var gribDataColumns = []string{
+	"missing_count integer",
+	"reference_value real",
+	"binary_scale smallint",
+	"decimal_scale smallint",
+	"discipline smallint",
+	"category smallint",
+	"number smallint",
+	"unit text",
+	"short_name text",
+	"standard_name text",
+	"legend jsonb",
+	"window_start timestamp without time zone",
+	"window_end timestamp without time zone",
+	"statistical_process smallint",
+	"step_type text",
+	"ensemble_type smallint",
+	"ensemble_member smallint",
+	"ensemble_size smallint",
+	"ensemble_product text",
+	"interpolated boolean",
+	"value_min double precision",
+	"value_max double precision",
+	"value_mean double precision",
+	"value_std double precision",
+	"value_percentiles jsonb",
+}
+
 // migrateGribData Создает таблицы для данных
 func migrateGribData() {
 	createTableSQL := `CREATE TABLE IF NOT EXISTS grib_data
@@ -49,6 +79,31 @@ func migrateGribData() {
 		grid_properties json,
 		grib_data double precision[],
 		grib_data_int integer[],
+		missing_count integer,
+		reference_value real,
+		binary_scale smallint,
+		decimal_scale smallint,
+		discipline smallint,
+		category smallint,
+		number smallint,
+		unit text,
+		short_name text,
+		standard_name text,
+		legend jsonb,
+		window_start timestamp without time zone,
+		window_end timestamp without time zone,
+		statistical_process smallint,
+		step_type text,
+		ensemble_type smallint,
+		ensemble_member smallint,
+		ensemble_size smallint,
+		ensemble_product text,
+		interpolated boolean,
+		value_min double precision,
+		value_max double precision,
+		value_mean double precision,
+		value_std double precision,
+		value_percentiles jsonb,
 		CONSTRAINT grib_data_pkey PRIMARY KEY (id)
 	)`
 
@@ -74,6 +129,31 @@ func migrateGribData() {
 		grid_properties json,
 		grib_data double precision[],
 		grib_data_int integer[],
+		missing_count integer,
+		reference_value real,
+		binary_scale smallint,
+		decimal_scale smallint,
+		discipline smallint,
+		category smallint,
+		number smallint,
+		unit text,
+		short_name text,
+		standard_name text,
+		legend jsonb,
+		window_start timestamp without time zone,
+		window_end timestamp without time zone,
+		statistical_process smallint,
+		step_type text,
+		ensemble_type smallint,
+		ensemble_member smallint,
+		ensemble_size smallint,
+		ensemble_product text,
+		interpolated boolean,
+		value_min double precision,
+		value_max double precision,
+		value_mean double precision,
+		value_std double precision,
+		value_percentiles jsonb,
 		CONSTRAINT grib_data_buff_pkey PRIMARY KEY (id)
 	)`
 
@@ -83,10 +163,53 @@ func migrateGribData() {
 		conn.Release()
 		os.Exit(11)
 	}
+	// Добавление колонок, появившихся после создания таблиц
+	for _, table := range []string{"grib_data", "grib_data_buff"} {
+		for _, column := range gribDataColumns {
+			_, err = conn.Exec(context.Background(), fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, column))
+			if err != nil {
+				config.Logger.WithError(err).Error("Ошибка выполнения запроса изменения таблицы")
+				conn.Release()
+				os.Exit(11)
+			}
+		}
+	}
 	config.Logger.Info("Таблицы готова к работе!")
 	conn.Release()
 }
 
+// migrateValueType Приводит тип колонки grib_data к типу значений из VALUE_TYPE
+func migrateValueType(valueType string) {
+	// Имена типов массивов в information_schema
+	udtName, columnType := "_float8", "double precision[]"
+	if valueType == "float32" {
+		udtName, columnType = "_float4", "real[]"
+	}
+	conn, err := Dbpool.Acquire(context.Background())
+	if err != nil {
+		config.Logger.WithError(err).Error("Ошибка соединения с БД при изменении таблицы")
+		os.Exit(11)
+	}
+	defer conn.Release()
+	for _, table := range []string{"grib_data", "grib_data_buff"} {
+		var current string
+		err = conn.QueryRow(context.Background(), "SELECT udt_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 AND column_name = 'grib_data'", table).Scan(&current)
+		if err != nil {
+			config.Logger.WithError(err).Error("Ошибка получения типа колонки grib_data")
+			os.Exit(11)
+		}
+		if current == udtName {
+			continue
+		}
+		config.Logger.Info("Изменение типа колонки grib_data таблицы ", table, " на ", columnType)
+		_, err = conn.Exec(context.Background(), fmt.Sprintf("ALTER TABLE %s ALTER COLUMN grib_data TYPE %s", table, columnType))
+		if err != nil {
+			config.Logger.WithError(err).Error("Ошибка выполнения запроса изменения таблицы")
+			os.Exit(11)
+		}
+	}
+}
+
 // migrateHash Создает таблицу, в которой хранятся хеш-суммы прочитанных файлов
 func migrateHash() {
 	tableName := "hashes"
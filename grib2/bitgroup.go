@@ -30,19 +30,39 @@ func (bitGroup *bitGroupParameter) readData(bitReader *reader.BitReader) ([]int6
 	return bitGroup.zeroGroup(), err
 }
 
-func checkLengths(bitGroups []bitGroupParameter, dataLength int) error {
-	totBit := 0
-	totLen := 0
+// checkLengths Проверяет длины и ширины групп до выделения памяти под значения и возвращает
+// общее количество значений. Длины групп записываются разрядностью до 64 бит, поэтому суммы
+// считаются в uint64, а каждая длина ограничивается количеством значений из Секции 5:
+// иначе огромные длины могли бы в сумме переполниться до допустимого числа
+func checkLengths(bitGroups []bitGroupParameter, dataLength int, points uint32) (uint64, error) {
+	var totBit, totLen uint64
+	dataBits := uint64(dataLength) * 8
 
 	for _, param := range bitGroups {
-		totBit += int(param.Width) * int(param.Length)
-		totLen += int(param.Length)
+		if err := checkLimit("длина группы", param.Length, DecodeLimits.MaxPoints); err != nil {
+			return 0, err
+		}
+		if param.Length > uint64(points) {
+			return 0, fmt.Errorf("%w: длина группы %d больше количества значений %d", ErrCorruptData, param.Length, points)
+		}
+		if param.Width > 64 {
+			return 0, fmt.Errorf("%w: ширина группы %d бит", ErrCorruptData, param.Width)
+		}
+		totLen += param.Length
+		if totLen > uint64(points) {
+			return 0, fmt.Errorf("%w: в группах больше значений, чем %d", ErrCorruptData, points)
+		}
+		groupBits := param.Width * param.Length
+		if groupBits > dataBits-totBit {
+			return 0, fmt.Errorf("%w: Checksum err %d - %d", ErrLengthMismatch, dataLength, (totBit+groupBits)/8)
+		}
+		totBit += groupBits
 	}
-	if totBit/8 > int(dataLength) {
-		return fmt.Errorf("Checksum err %d - %d", dataLength, totBit/8)
+	if totLen != uint64(points) {
+		return 0, fmt.Errorf("%w: в группах %d значений, в Секции 5 %d", ErrCorruptData, totLen, points)
 	}
 
-	return nil
+	return totLen, nil
 }
 
 func (template *Data2) extractGroupReferences(bitReader *reader.BitReader) ([]uint64, error) {
@@ -78,21 +98,29 @@ func (template *Data2) extractGroupLengths(bitReader *reader.BitReader) ([]uint6
 	return lengths, nil
 }
 
-func (template *Data2) extractBitGroupParameters(bitReader *reader.BitReader) ([]bitGroupParameter, error) {
+// extractBitGroupParameters Читает опорные значения, ширины и длины групп и проверяет их по длине
+// Секции 7 dataLength и количеству значений points. Возвращает группы и общее количество значений
+func (template *Data2) extractBitGroupParameters(bitReader *reader.BitReader, dataLength int, points uint32) ([]bitGroupParameter, uint64, error) {
 	result := []bitGroupParameter{}
+	if template.NG == 0 {
+		return result, 0, fmt.Errorf("%w: количество групп равно 0", ErrCorruptData)
+	}
+	if err := checkLimit("количество групп", uint64(template.NG), DecodeLimits.MaxGroups); err != nil {
+		return result, 0, err
+	}
 	references, err := template.extractGroupReferences(bitReader)
 	if err != nil {
-		return result, err
+		return result, 0, err
 	}
 
 	widths, err := template.extractGroupBitWidths(bitReader)
 	if err != nil {
-		return result, err
+		return result, 0, err
 	}
 
 	lengths, err := template.extractGroupLengths(bitReader)
 	if err != nil {
-		return result, err
+		return result, 0, err
 	}
 
 	for index := range references {
@@ -105,5 +133,10 @@ func (template *Data2) extractBitGroupParameters(bitReader *reader.BitReader) ([
 
 	bitReader.ResetOffset()
 
-	return result, nil
+	totalLength, err := checkLengths(result, dataLength, points)
+	if err != nil {
+		return result, 0, err
+	}
+
+	return result, totalLength, nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	ch "gribV2.com/clickhouse"
 	"gribV2.com/config"
+	"strings"
 	"time"
 )
 
@@ -16,9 +17,56 @@ const (
 	CHUNK_SIZE = 1600
 )
 
+// gridColumns Колонки таблицы свойств данных в порядке записи
+var gridColumns = []string{"id", "grib_datetime", "forecast_time", "parameter", "surface_type", "surface_value", "grid", "missing_count", "reference_value", "binary_scale", "decimal_scale", "discipline", "category", "number", "unit", "short_name", "standard_name", "legend", "window_start", "window_end", "statistical_process", "step_type", "ensemble_type", "ensemble_member", "ensemble_size", "ensemble_product", "interpolated", "value_min", "value_max", "value_mean", "value_std", "value_percentiles"}
+
+// gridInsertQuery Формирует запрос на вставку свойств данных в таблицу table
+func gridInsertQuery(table string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(gridColumns)), ",")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES(%s)", table, strings.Join(gridColumns, ", "), placeholders)
+}
+
+// gridValues Возвращает значения свойств данных в порядке gridColumns
+func gridValues(item *Table, grid string) []interface{} {
+	legend := item.Legend
+	if legend == nil {
+		legend = map[string]string{}
+	}
+	values := []interface{}{
+		item.UUID,
+		item.Date,
+		item.ForecastTime,
+		item.Parameter.Name,
+		item.SurfaceType,
+		item.SurfaceValue,
+		grid,
+		int32(item.MissingCount),
+		item.Reference,
+		item.BinaryScale,
+		item.DecimalScale,
+		item.Parameter.Discipline,
+		item.Parameter.Category,
+		item.Parameter.Number,
+		item.Parameter.Unit,
+		item.Parameter.ShortName,
+		item.Parameter.StandardName,
+		legend,
+		item.WindowStart,
+		item.WindowEnd,
+		item.StatisticalProcess,
+		item.StepType,
+		item.EnsembleType,
+		item.EnsembleMember,
+		item.EnsembleSize,
+		item.EnsembleProduct,
+		item.Interpolated,
+	}
+	return append(values, statisticsValues(item.Statistics)...)
+}
+
 // chunkIntSlice нарезает большой массив данных на более маленькие для лучшей отправки и доступа к данным из БД
-func chunkIntSlice(slice []int, chunkSize int) [][]int {
-	var chunks [][]int
+func chunkIntSlice(slice []int32, chunkSize int) [][]int32 {
+	var chunks [][]int32
 	for i := 0; i < len(slice); i += chunkSize {
 		end := i + chunkSize
 		if end > len(slice) {
@@ -62,24 +110,25 @@ func ExportBatch(bufChannel chan *Table, cfg *config.Config) error {
 	config.Logger.Info("Количество записанных файлов: ",count_file)
 
 	// Проверка времени и количества файлов для определения таблицы
-	var q_grid string
+	var gridTable string
 	if count_file < 42 {
 		if hour >= 1 && hour < 12 {
 			tableName = "grib_data"
-			q_grid = "INSERT INTO grid (id, grib_datetime, forecast_time, parameter, surface_type, surface_value, grid) VALUES(?,?,?,?,?,?,?)"
+			gridTable = "grid"
 		} else if hour == 12 || hour == 0 {
 			tableName = "grib_data_buff"
-			q_grid = "INSERT INTO grid_buff (id, grib_datetime, forecast_time, parameter, surface_type, surface_value, grid) VALUES(?,?,?,?,?,?,?)"
+			gridTable = "grid_buff"
 		} else if hour >= 13 && hour < 24 {
 			tableName = "grib_data"
-			q_grid = "INSERT INTO grid (id, grib_datetime, forecast_time, parameter, surface_type, surface_value, grid) VALUES(?,?,?,?,?,?,?)"
+			gridTable = "grid"
 		} else {
 			panic("Проблема с определением времени!")
 		}
 	} else {
-		q_grid = "INSERT INTO grid (id, grib_datetime, forecast_time, parameter, surface_type, surface_value, grid) VALUES(?,?,?,?,?,?,?)"
+		gridTable = "grid"
 		tableName="grib_data_buff"
 	}
+	q_grid := gridInsertQuery(gridTable)
 
 
 	// Текущий размер пачки
@@ -152,6 +201,13 @@ func ExportBatch(bufChannel chan *Table, cfg *config.Config) error {
 			// Нарезка массивов данных на маленькие чанки
 			chunkInt := chunkIntSlice(item.Data_int, CHUNK_SIZE)
 			chunkFloat := chunkFloat64Slice(item.Data, CHUNK_SIZE)
+			// Без сохранения кодов в каждый чанк записывается пустой массив кодов
+			if len(item.Data_int) == 0 {
+				chunkInt = make([][]int32, len(chunkFloat))
+				for i := range chunkInt {
+					chunkInt[i] = []int32{}
+				}
+			}
 			if len(chunkFloat) != len(chunkInt) {
 				clickhouseConn.Close()
 				return errors.New("Массивы не совпадают")
@@ -164,15 +220,7 @@ func ExportBatch(bufChannel chan *Table, cfg *config.Config) error {
 			// Преобразование json в строку для лучшей записи
 			jString := string(jsonData)
 			// Запись в БД параметров сетки и даных для текущего сообщения
-			err = clickhouseConn.Exec(context.Background(), q_grid,
-				item.UUID,
-				item.Date,
-				item.ForecastTime,
-				item.Param,
-				item.SurfaceType,
-				item.SurfaceValue,
-				jString,
-			)
+			err = clickhouseConn.Exec(context.Background(), q_grid, gridValues(item, jString)...)
 			if err != nil {
 				return err
 			}
@@ -182,7 +230,7 @@ func ExportBatch(bufChannel chan *Table, cfg *config.Config) error {
 				index := i * CHUNK_SIZE
 				err := batch.Append(
 					item.UUID,
-					chunkFloat[i],
+					Values(chunkFloat[i]).Column(),
 					chunkInt[i],
 					index,
 				)
@@ -0,0 +1,54 @@
+package grib2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConstantField(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name    string
+		message []byte
+		data    Values
+		missing int
+	}{
+		{
+			name:    "простая упаковка",
+			message: simpleMessage(3, 2, make([]uint64, 6), 0, 250, 0, 0, 0),
+			data:    Values{250, 250, 250, 250, 250, 250},
+		},
+		{
+			name:    "сложная упаковка, одна группа",
+			message: complexPacking{groups: []testGroup{{0, 0, make([]uint64, 5)}}}.encode(),
+			data:    Values{100, 100, 100, 100, 100},
+		},
+		{
+			name:    "сложная упаковка с группами",
+			message: complexPacking{groups: []testGroup{{0, 2, []uint64{0, 3, 1}}, {0, 0, make([]uint64, 2)}}}.encode(),
+			data:    Values{100, 103, 101, 100, 100},
+		},
+		{
+			name:    "сложная упаковка с пропусками",
+			message: complexPacking{management: 1, groups: []testGroup{{0, 2, []uint64{0, 3, 1}}, {0, 0, make([]uint64, 2)}}}.encode(),
+			data:    Values{100, nan, 101, nan, nan},
+			missing: 3,
+		},
+		{
+			name:    "дифференцирование",
+			message: complexPacking{spatial: true, ival1: 5, minsd: 2, groups: []testGroup{{0, 0, make([]uint64, 5)}}}.encode(),
+			data:    Values{105, 107, 109, 111, 113},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, err := decodeBytes(t, test.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameValues(message.Section7.Data, test.data) || message.Section7.Missing != test.missing {
+				t.Fatalf("значения %v, пропусков %d; ожидалось %v, %d", message.Section7.Data, message.Section7.Missing, test.data, test.missing)
+			}
+		})
+	}
+}
@@ -11,66 +11,116 @@ const INT_MAX = 9223372036854775807
 
 type Data0 struct {
 	Reference    float32 `json:"reference"`
-	BinaryScale  uint16  `json:"binaryScale"`
-	DecimalScale uint16  `json:"decimalScale"`
+	BinaryScale  int16   `json:"binaryScale"`
+	DecimalScale int16   `json:"decimalScale"`
 	Bits         uint8   `json:"bits"`
 	Type         uint8   `json:"type"`
 }
 
+// getRefScale Возвращает опорное значение и шаг упаковки для формулы Y = (R + X * 2^E) / 10^D
 func (template Data0) getRefScale() (float64, float64) {
-	mask := uint16(1 << 15) // Маска для наиболее значимого бита
-	switch (template.BinaryScale & mask) != 0 {
-	case true:
-		// есть единица
-		m := template.BinaryScale & 0x7FFF
-		bscale := math.Pow(2.0, -float64(m))
-		dscale := math.Pow(10.0, -float64(template.DecimalScale))
-		scale := bscale * dscale
-		ref := dscale * float64(template.Reference)
-		return ref, scale
-
-	case false:
-		// нет
-		bscale := math.Pow(2.0, float64(template.BinaryScale))
-		dscale := math.Pow(10.0, float64(template.DecimalScale))
-		scale := bscale * dscale
-		ref := dscale * float64(template.Reference)
-		return ref, scale
+	binaryScale := template.BinaryScale
+	if binaryScale == MissingInt16 {
+		binaryScale = 0
+	}
+	decimalScale := template.DecimalScale
+	if decimalScale == MissingInt16 {
+		decimalScale = 0
 	}
+	dscale := math.Pow(10.0, -float64(decimalScale))
+	bscale := math.Pow(2.0, float64(binaryScale))
+	return dscale * float64(template.Reference), bscale * dscale
+}
 
-	return 0, 0
+// fixSigned Переводит двоичный и десятичный масштабные множители из прямого кода
+func (template *Data0) fixSigned() {
+	template.BinaryScale = signed16(template.BinaryScale)
+	template.DecimalScale = signed16(template.DecimalScale)
 }
 
 func (template Data0) scaleFunc() func(uintValue int64) float64 {
 
 	ref, scale := template.getRefScale()
+	round := template.roundFunc()
 	return func(value int64) float64 {
 		signed := int64(value)
-		return ref + float64(signed)*scale
+		return round(ref + float64(signed)*scale)
 	}
 }
 
-func ParseData0(dataReader io.Reader, dataLength int, template *Data0) ([]float64, error) {
-
-	fld := []float64{}
-	if dataLength == 0 {
-		return fld, nil
-	}
-	scaleStrategy := template.scaleFunc()
+// unpackData0 Читает упакованные целые коды значений при простой упаковке
+func unpackData0(dataReader io.Reader, dataLength int, template *Data0) ([]int64, error) {
 	bitReader, err := reader.New(dataReader, dataLength)
 	if err != nil {
-		return fld, err
+		return nil, err
 	}
 	//количество данных
-	dataSize := int64(math.Floor(
-		float64(8*dataLength) / float64(template.Bits),
-	))
+	var dataSize int64
+	if template.Bits != 0 {
+		dataSize = int64(8*dataLength) / int64(template.Bits)
+	}
+	if err := checkLimit("количество значений", uint64(dataSize), DecodeLimits.MaxPoints); err != nil {
+		return nil, err
+	}
 	uintDataSlice, errRead := bitReader.ReadUintsBlock(int(template.Bits), dataSize, false)
 	if errRead != nil {
-		return []float64{}, errRead
+		return nil, errRead
+	}
+	codes := make([]int64, len(uintDataSlice))
+	for i, uintValue := range uintDataSlice {
+		codes[i] = int64(uintValue)
+	}
+	return codes, nil
+}
+
+// scaleCodes Переводит упакованные коды в значения
+func (template Data0) scaleCodes(codes []int64) []float64 {
+	scaleStrategy := template.scaleFunc()
+	fld := make([]float64, len(codes))
+	for i, code := range codes {
+		fld[i] = scaleStrategy(code)
+	}
+	return fld
+}
+
+func ParseData0(dataReader io.Reader, dataLength int, template *Data0) ([]float64, error) {
+	if dataLength == 0 {
+		return []float64{}, nil
+	}
+	codes, err := unpackData0(dataReader, dataLength, template)
+	if err != nil {
+		return []float64{}, err
+	}
+	return template.scaleCodes(codes), nil
+}
+
+// ParseConstant Возвращает поле из points копий опорного значения. Используется, когда ширина
+// упаковки равна 0: все значения совпадают и Секция 7 не содержит данных
+func ParseConstant(template *Data0, points uint32) ([]float64, error) {
+	if err := checkLimit("количество значений", uint64(points), DecodeLimits.MaxPoints); err != nil {
+		return nil, err
 	}
-	for _, uintValue := range uintDataSlice {
-		fld = append(fld, scaleStrategy(int64(uintValue)))
+	value := template.scaleFunc()(0)
+	fld := make([]float64, points)
+	for i := range fld {
+		fld[i] = value
 	}
 	return fld, nil
 }
+
+// constantField Сообщает, что поле постоянное и его значения не зависят от Секции 7 длиной length.
+// При простой упаковке это нулевая ширина значений. При сложной упаковке нулевая разрядность
+// опорных значений групп не отменяет ширин групп и пропусков (Code Table 5.5), а при 5.3 — еще
+// и начальных значений дифференцирования, поэтому поле постоянно только без пропусков, с одной
+// группой нулевой ширины и, для 5.3, без данных в Секции 7
+func constantField(template interface{}, length int) bool {
+	switch x := template.(type) {
+	case Data0:
+		return x.Bits == 0
+	case Data2:
+		return x.Bits == 0 && x.constantGroups()
+	case Data3:
+		return x.Bits == 0 && x.constantGroups() && length == 0
+	}
+	return false
+}
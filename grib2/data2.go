@@ -3,6 +3,7 @@ package grib2
 import (
 	"fmt"
 	"io"
+	"math"
 
 	"gribV2.com/grib2/reader"
 )
@@ -63,132 +64,132 @@ type Data2 struct {
 	GroupScaledLengthsBits uint8  `json:"groupScaledLengthsBits"` // 47
 }
 
-func (template *Data2) missingValueSubstitute() (float64, float64) {
-	var missingValueSubstitute1 float64
-	var missingValueSubstitute2 float64
-	if template.MissingValue == 1 {
-		missingValueSubstitute1 = float64(template.MissingSubstitute1)
-	} else if template.MissingValue == 2 {
-		missingValueSubstitute1 = float64(template.MissingSubstitute1)
-		missingValueSubstitute2 = float64(template.MissingSubstitute1)
+// constantGroups Сообщает, что в поле нет пропусков, а все значения входят в одну группу нулевой ширины
+func (template *Data2) constantGroups() bool {
+	return template.MissingValue == 0 && template.NG <= 1 && template.GroupWidths == 0 && template.GroupWidthsBits == 0
+}
+
+// substituteValue Интерпретирует заменитель пропуска согласно типу исходных значений поля (Code Table 5.1)
+func (template *Data2) substituteValue(raw uint32) float64 {
+	if template.Type == 1 {
+		return float64(raw)
+	}
+	return float64(math.Float32frombits(raw))
+}
+
+// MissingValueSubstitutes Возвращает первичный и вторичный заменители пропусков из Секции 5.
+// Если заменитель не используется, возвращается NaN
+func (template *Data2) MissingValueSubstitutes() (float64, float64) {
+	missingValueSubstitute1 := math.NaN()
+	missingValueSubstitute2 := math.NaN()
+	if template.MissingValue == 1 || template.MissingValue == 2 {
+		missingValueSubstitute1 = template.substituteValue(template.MissingSubstitute1)
+	}
+	if template.MissingValue == 2 {
+		missingValueSubstitute2 = template.substituteValue(template.MissingSubstitute2)
 	}
 	return missingValueSubstitute1, missingValueSubstitute2
 }
 
-func (template *Data2) scaleValues(section7Data []int64, ifldmiss []int64) []float64 {
+// missingValueSubstitute Возвращает значения, записываемые в первичные и вторичные пропуски
+func (template *Data2) missingValueSubstitute() (float64, float64) {
+	if UseMissingSubstitutes {
+		return template.MissingValueSubstitutes()
+	}
+	return MissingValue, MissingValue
+}
+
+func (template *Data2) scaleValues(section7Data []int64, ifldmiss []int64) ([]float64, int) {
 	fld := make([]float64, len(section7Data))
+	missing := 0
 
 	scaleStrategy := template.scaleFunc()
 	missingValueSubstitute1, missingValueSubstitute2 := template.missingValueSubstitute()
 
-	if template.MissingValue == 0 {
-		for n, dataValue := range section7Data {
+	for n, dataValue := range section7Data {
+		switch ifldmiss[n] {
+		case 0:
 			fld[n] = scaleStrategy(dataValue)
-		}
-	}
-	if template.MissingValue == 1 || template.MissingValue == 2 {
-		for n, dataValue := range section7Data {
-			switch ifldmiss[n] {
-			case 0:
-				fld[n] = scaleStrategy(dataValue)
-			case 1:
-				fld[n] = missingValueSubstitute1
-			case 2:
-				fld[n] = missingValueSubstitute2
-			}
+		case 1:
+			fld[n] = missingValueSubstitute1
+			missing++
+		case 2:
+			fld[n] = missingValueSubstitute2
+			missing++
 		}
 	}
 
-	return fld
+	return fld, missing
 }
 
-func (template *Data2) extractData(bitReader *reader.BitReader, bitGroups []bitGroupParameter) ([]int64, []int64, error) {
-	var totalLength uint64
-	for _, group := range bitGroups {
-		totalLength += group.Length
-	}
+// extractData Читает значения групп. Пропуски отмечаются в ifldmiss: 1 — первичный, 2 — вторичный.
+// Для группы нулевой ширины пропуском является опорное значение группы из одних единиц,
+// для остальных групп — значение из одних единиц в пределах ширины группы.
+// totalLength — проверенное checkLengths общее количество значений в группах
+func (template *Data2) extractData(bitReader *reader.BitReader, bitGroups []bitGroupParameter, totalLength uint64) ([]int64, []int64, error) {
 	section7Data := make([]int64, totalLength)
-	ifldmiss := make([]int64, 0, totalLength)
+	ifldmiss := make([]int64, totalLength)
 	s7i := 0
 
 	for _, bitGroup := range bitGroups {
 		tmp, err := bitGroup.readData(bitReader)
 		if err != nil {
-			return section7Data, ifldmiss, fmt.Errorf("bitGroup read: %s", err.Error())
+			return section7Data, ifldmiss, fmt.Errorf("bitGroup read: %w", err)
 		}
 
 		missingValueBits := bitGroup.Width
 		if missingValueBits == 0 {
 			missingValueBits = uint64(template.Bits)
 		}
-
 		missingValues := []uint64{1<<missingValueBits - 1, 1<<missingValueBits - 2}
 
-		switch template.MissingValue {
-		case 0:
-			ifldmiss = append(ifldmiss, make([]int64, len(tmp))...)
-			for _, elt := range tmp {
-				section7Data[s7i] = elt + int64(bitGroup.Reference)
-				s7i++
-			}
-
-		case 1:
-			for _, elt := range tmp {
-				if bitGroup.Reference == missingValues[0] {
-					section7Data[s7i] = -1
-					s7i++
-					ifldmiss = append(ifldmiss, 1)
-				} else {
-					section7Data[s7i] = elt + int64(bitGroup.Reference)
-					s7i++
-					ifldmiss = append(ifldmiss, 0)
-				}
+		for _, elt := range tmp {
+			value := uint64(elt)
+			if bitGroup.Width == 0 {
+				value = bitGroup.Reference
 			}
-
-		case 2:
-			for _, elt := range tmp {
-				if bitGroup.Reference == missingValues[0] || bitGroup.Reference == missingValues[1] {
-					section7Data[s7i] = -1
-					s7i++
-
-					if bitGroup.Reference == missingValues[0] {
-						ifldmiss = append(ifldmiss, 1)
-					} else {
-						ifldmiss = append(ifldmiss, 2)
-					}
-				} else {
-					section7Data[s7i] = elt + int64(bitGroup.Reference)
-					s7i++
-
-					ifldmiss = append(ifldmiss, 0)
-				}
+			switch {
+			case template.MissingValue >= 1 && template.MissingValue <= 2 && value == missingValues[0]:
+				ifldmiss[s7i] = 1
+			case template.MissingValue == 2 && value == missingValues[1]:
+				ifldmiss[s7i] = 2
+			default:
+				section7Data[s7i] = elt + int64(bitGroup.Reference)
 			}
+			s7i++
 		}
 	}
 
 	return section7Data, ifldmiss, nil
 }
 
-func ParseData2(dataReader io.Reader, dataLength int, template *Data2) ([]float64, error) {
+// unpackData2 Читает упакованные целые коды значений при сложной упаковке и отметки пропусков.
+// points — количество значений из Секции 5
+func unpackData2(dataReader io.Reader, dataLength int, points uint32, template *Data2) ([]int64, []int64, error) {
 
 	bitReader, err := reader.New(dataReader, dataLength)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	bitGroups, err := template.extractBitGroupParameters(bitReader)
+	bitGroups, totalLength, err := template.extractBitGroupParameters(bitReader, dataLength, points)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if err := checkLengths(bitGroups, dataLength); err != nil {
-		return nil, err
+	section7Data, ifldmiss, err := template.extractData(bitReader, bitGroups, totalLength)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Data extract: %w", err)
 	}
+	return section7Data, ifldmiss, nil
+}
 
-	section7Data, ifldmiss, err := template.extractData(bitReader, bitGroups)
+// ParseData2 Декодирует points значений при сложной упаковке и возвращает их вместе с количеством пропусков
+func ParseData2(dataReader io.Reader, dataLength int, points uint32, template *Data2) ([]float64, int, error) {
+	section7Data, ifldmiss, err := unpackData2(dataReader, dataLength, points, template)
 	if err != nil {
-		return nil, fmt.Errorf("Data extract: %s", err.Error())
+		return nil, 0, err
 	}
-
-	return template.scaleValues(section7Data, ifldmiss), nil
+	values, missing := template.scaleValues(section7Data, ifldmiss)
+	return values, missing, nil
 }
@@ -13,21 +13,32 @@ type Data3 struct {
 	OctetsNumber           uint8 `json:"octetsNumber"`
 }
 
-func (template *Data3) applySpacialDifferencing(section7Data []int64, minsd int64, ival1 int64, ival2 int64) {
+// applySpacialDifferencing Восстанавливает значения после пространственного дифференцирования.
+// Пропуски в дифференцировании не участвуют, поэтому оно применяется только к присутствующим значениям
+func (template *Data3) applySpacialDifferencing(section7Data []int64, ifldmiss []int64, minsd int64, ival1 int64, ival2 int64) {
+	present := make([]int, 0, len(section7Data))
+	for n := range section7Data {
+		if ifldmiss[n] == 0 {
+			present = append(present, n)
+		}
+	}
+	if len(present) < int(template.SpatialOrderDifference) {
+		return
+	}
 	switch template.SpatialOrderDifference {
 	case 1:
-		section7Data[0] = ival1
+		section7Data[present[0]] = ival1
 
-		for n := int(1); n < len(section7Data); n++ {
-			section7Data[n] = section7Data[n] + section7Data[n-1] + minsd
+		for n := int(1); n < len(present); n++ {
+			section7Data[present[n]] = section7Data[present[n]] + section7Data[present[n-1]] + minsd
 		}
 	case 2:
 
-		section7Data[0] = ival1
-		section7Data[1] = ival2
+		section7Data[present[0]] = ival1
+		section7Data[present[1]] = ival2
 
-		for n := int(2); n < len(section7Data); n++ {
-			section7Data[n] = section7Data[n] + (2 * section7Data[n-1]) - section7Data[n-2] + minsd
+		for n := int(2); n < len(present); n++ {
+			section7Data[present[n]] = section7Data[present[n]] + (2 * section7Data[present[n-1]]) - section7Data[present[n-2]] + minsd
 		}
 	}
 }
@@ -42,52 +53,61 @@ func (template *Data3) extractSpacingDifferentialValues(bitReader *reader.BitRea
 		var err error
 		ival1, err = bitReader.ReadInt(rc)
 		if err != nil {
-			return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Value 1: %s", err.Error())
+			return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Value 1: %w", err)
 		}
 
 		if template.SpatialOrderDifference == 2 {
 			ival2, err = bitReader.ReadInt(rc)
 			if err != nil {
-				return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Value 2: %s", err.Error())
+				return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Value 2: %w", err)
 			}
 		}
 
 		minsd, err = bitReader.ReadInt(rc)
 		if err != nil {
-			return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Reference: %s", err.Error())
+			return minsd, ival1, ival2, fmt.Errorf("Spacial differencing Reference: %w", err)
 		}
 	}
 
 	return minsd, ival1, ival2, nil
 }
 
-func ParseData3(dataReader io.Reader, dataLength int, template *Data3) ([]float64, error) {
+// unpackData3 Читает упакованные целые коды значений при сложной упаковке с пространственным
+// дифференцированием и отметки пропусков. Дифференцирование к кодам уже применено.
+// points — количество значений из Секции 5
+func unpackData3(dataReader io.Reader, dataLength int, points uint32, template *Data3) ([]int64, []int64, error) {
 
 	bitReader, err := reader.New(dataReader, dataLength)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	minsd, ival1, ival2, err := template.extractSpacingDifferentialValues(bitReader)
 	if err != nil {
-		return nil, fmt.Errorf("Spacial differencing Value 1: %s", err.Error())
+		return nil, nil, fmt.Errorf("Spacial differencing Value 1: %w", err)
 	}
 
-	bitGroups, err := template.extractBitGroupParameters(bitReader)
+	bitGroups, totalLength, err := template.extractBitGroupParameters(bitReader, dataLength, points)
 	if err != nil {
-		return nil, fmt.Errorf("Groups: %s", err.Error())
-	}
-
-	if err := checkLengths(bitGroups, dataLength); err != nil {
-		return nil, fmt.Errorf("Check length: %s", err.Error())
+		return nil, nil, fmt.Errorf("Groups: %w", err)
 	}
 
-	section7Data, ifldmiss, err := template.extractData(bitReader, bitGroups)
+	section7Data, ifldmiss, err := template.extractData(bitReader, bitGroups, totalLength)
 	if err != nil {
-		return nil, fmt.Errorf("Data extract: %s", err.Error())
+		return nil, nil, fmt.Errorf("Data extract: %w", err)
 	}
 
-	template.applySpacialDifferencing(section7Data, minsd, ival1, ival2)
+	template.applySpacialDifferencing(section7Data, ifldmiss, minsd, ival1, ival2)
+	return section7Data, ifldmiss, nil
+}
 
-	return template.scaleValues(section7Data, ifldmiss), nil
+// ParseData3 Декодирует значения при сложной упаковке с пространственным дифференцированием
+// и возвращает points значений вместе с количеством пропусков
+func ParseData3(dataReader io.Reader, dataLength int, points uint32, template *Data3) ([]float64, int, error) {
+	section7Data, ifldmiss, err := unpackData3(dataReader, dataLength, points, template)
+	if err != nil {
+		return nil, 0, err
+	}
+	values, missing := template.scaleValues(section7Data, ifldmiss)
+	return values, missing, nil
 }
@@ -0,0 +1,455 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// DerivedInput Входное поле производного параметра
+type DerivedInput struct {
+	Discipline uint8
+	Category   uint8
+	Number     uint8
+	Unit       string // Единицы, в которых значения передаются в Compute
+	Surface    int    // Тип поверхности (Code table 4.5) фиксированного уровня
+	Level      string // Фиксированный уровень (SurfaceValue), пусто — уровень производного поля
+}
+
+// Derivation Правило вычисления производного параметра по значениям входных полей одного срока,
+// поверхности и сетки в каждой точке. Compute получает значения в порядке Inputs и возвращает
+// значение в единицах Unit, которое пересчитывается в единицы параметра из каталога.
+// Если у первого входа указан уровень, у остальных он тоже указывается, а производное поле
+// относится к уровню первого входа
+type Derivation struct {
+	Discipline uint8
+	Category   uint8
+	Number     uint8
+	Unit       string
+	Inputs     []DerivedInput
+	Compute    func(values []float64) float64
+}
+
+// Derivations Производные параметры по коротким названиям. Список можно дополнить своими правилами
+var Derivations = map[string]Derivation{
+	// Скорость ветра по компонентам
+	"WIND": {
+		Discipline: 0, Category: 2, Number: 1, Unit: "m s-1",
+		Inputs: []DerivedInput{{Discipline: 0, Category: 2, Number: 2, Unit: "m s-1"}, {Discipline: 0, Category: 2, Number: 3, Unit: "m s-1"}},
+		Compute: func(values []float64) float64 {
+			return math.Hypot(values[0], values[1])
+		},
+	},
+	// Направление, откуда дует ветер, в градусах по часовой стрелке от севера. При штиле 0
+	"WDIR": {
+		Discipline: 0, Category: 2, Number: 0, Unit: "deg true",
+		Inputs: []DerivedInput{{Discipline: 0, Category: 2, Number: 2, Unit: "m s-1"}, {Discipline: 0, Category: 2, Number: 3, Unit: "m s-1"}},
+		Compute: func(values []float64) float64 {
+			if values[0] == 0 && values[1] == 0 {
+				return 0
+			}
+			return math.Mod(degrees(math.Atan2(-values[0], -values[1]))+360, 360)
+		},
+	},
+	// Относительная влажность по температуре и точке росы, упругость пара по формуле Магнуса (Bolton, 1980)
+	"RH": {
+		Discipline: 0, Category: 1, Number: 1, Unit: "%",
+		Inputs: []DerivedInput{{Discipline: 0, Category: 0, Number: 0, Unit: "°C"}, {Discipline: 0, Category: 0, Number: 6, Unit: "°C"}},
+		Compute: func(values []float64) float64 {
+			return math.Max(0, math.Min(100, 100*vaporPressure(values[1])/vaporPressure(values[0])))
+		},
+	},
+	// Индекс жары NWS (Rothfusz, 1990) по температуре и относительной влажности на 2 м
+	"HEATX": {
+		Discipline: 0, Category: 0, Number: 12, Unit: "°F",
+		Inputs: []DerivedInput{
+			{Discipline: 0, Category: 0, Number: 0, Unit: "°F", Surface: 103, Level: "2m"},
+			{Discipline: 0, Category: 1, Number: 1, Unit: "%", Surface: 103, Level: "2m"},
+		},
+		Compute: func(values []float64) float64 {
+			return heatIndex(values[0], values[1])
+		},
+	},
+	// Ветро-холодовой индекс (Environment Canada, NWS, 2001) по температуре на 2 м и скорости ветра на 10 м
+	"WCF": {
+		Discipline: 0, Category: 0, Number: 13, Unit: "°C",
+		Inputs: []DerivedInput{
+			{Discipline: 0, Category: 0, Number: 0, Unit: "°C", Surface: 103, Level: "2m"},
+			{Discipline: 0, Category: 2, Number: 1, Unit: "km/h", Surface: 103, Level: "10m"},
+		},
+		Compute: func(values []float64) float64 {
+			return windChill(values[0], values[1])
+		},
+	},
+}
+
+// vaporPressure Возвращает насыщающую упругость водяного пара в гПа при температуре в °C
+func vaporPressure(celsius float64) float64 {
+	return 6.112 * math.Exp(17.67*celsius/(celsius+243.5))
+}
+
+// heatIndex Возвращает индекс жары в °F по температуре в °F и относительной влажности в %.
+// При индексе ниже 80 °F используется упрощенная формула Стедмана, при температуре до 40 °F индекс
+// равен температуре
+func heatIndex(t, rh float64) float64 {
+	if t <= 40 {
+		return t
+	}
+	index := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (index+t)/2 < 80 {
+		return index
+	}
+	index = -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+		0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	if rh < 13 && t >= 80 && t <= 112 {
+		index -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	} else if rh > 85 && t >= 80 && t <= 87 {
+		index += (rh - 85) / 10 * (87 - t) / 5
+	}
+	return index
+}
+
+// windChill Возвращает ветро-холодовой индекс в °C по температуре в °C и скорости ветра в км/ч.
+// При температуре выше 10 °C или ветре слабее 4.8 км/ч индекс равен температуре
+func windChill(t, wind float64) float64 {
+	if t > 10 || wind <= 4.8 {
+		return t
+	}
+	power := math.Pow(wind, 0.16)
+	return 13.12 + 0.6215*t - 11.37*power + 0.3965*t*power
+}
+
+// key Возвращает ключ параметра входного поля
+func (in DerivedInput) key() parameterKey {
+	return parameterKey{in.Discipline, in.Category, in.Number}
+}
+
+// key Возвращает ключ производного параметра
+func (d Derivation) key() parameterKey {
+	return parameterKey{d.Discipline, d.Category, d.Number}
+}
+
+// fieldKey Возвращает ключ параметра поля
+func fieldKey(field *Table) parameterKey {
+	return parameterKey{field.Parameter.Discipline, field.Parameter.Category, field.Parameter.Number}
+}
+
+// Derive Вычисляет производный параметр name из Derivations по входным полям в порядке его
+// Inputs, например Derive("WIND", u, v). Значения входов пересчитываются в нужные единицы,
+// точка без значения хотя бы у одного входа остается пропущенной. Компоненты векторов
+// относительно осей сетки в проекции сначала поворачиваются на восток и на север, компонента
+// без парной среди входов не вычисляется (ErrGridRelative). Метаданные поля берутся у первого входа
+func Derive(name string, inputs ...*Table) (*Table, error) {
+	derivation, ok := Derivations[name]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный производный параметр %q", name)
+	}
+	if len(inputs) != len(derivation.Inputs) {
+		return nil, fmt.Errorf("для %s нужно %d входных полей, передано %d", name, len(derivation.Inputs), len(inputs))
+	}
+	conversions := make([]UnitConversion, len(inputs))
+	for i, input := range derivation.Inputs {
+		field := inputs[i]
+		if fieldKey(field) != input.key() {
+			return nil, fmt.Errorf("%s: вход %d — параметр %s, ожидается %d.%d.%d", name, i+1, field.Parameter.ShortName, input.Discipline, input.Category, input.Number)
+		}
+		if len(field.Data) != len(inputs[0].Data) {
+			return nil, fmt.Errorf("%s: входы %s и %s имеют разный размер: %d и %d", name, inputs[0].Parameter.ShortName, field.Parameter.ShortName, len(inputs[0].Data), len(field.Data))
+		}
+		if conversions[i], ok = Tables.Conversion(field.Parameter.Unit, input.Unit); !ok {
+			return nil, fmt.Errorf("%s: нет пересчета %s из %q в %q", name, field.Parameter.ShortName, field.Parameter.Unit, input.Unit)
+		}
+	}
+	inputs, err := earthRelative(name, inputs)
+	if err != nil {
+		return nil, err
+	}
+	parameter := LookupParameter(inputs[0].tables(), derivation.Discipline, derivation.Category, derivation.Number)
+	output, ok := Tables.Conversion(derivation.Unit, parameter.Unit)
+	if !ok {
+		// Параметра нет в каталоге или его единицы не пересчитываются: значения остаются в единицах правила
+		output = UnitConversion{From: derivation.Unit, To: derivation.Unit, Scale: 1}
+		parameter.Unit = derivation.Unit
+	}
+	data := make(Values, len(inputs[0].Data))
+	values := make([]float64, len(inputs))
+	for j := range data {
+		data[j] = MissingValue
+		missing := false
+		for i, field := range inputs {
+			if IsMissing(field.Data[j]) {
+				missing = true
+				break
+			}
+			values[i] = conversions[i].Apply(field.Data[j])
+		}
+		if missing {
+			continue
+		}
+		if value := derivation.Compute(values); !math.IsNaN(value) && !math.IsInf(value, 0) {
+			data[j] = output.Apply(value)
+		}
+	}
+	field := inputs[0].derive(data)
+	field.Parameter = parameter
+	field.Legend = nil
+	return field, nil
+}
+
+// earthRelative Возвращает входы, в которых пары компонент векторов относительно осей сетки
+// заменены компонентами на восток и на север
+func earthRelative(name string, inputs []*Table) ([]*Table, error) {
+	rotated := append([]*Table(nil), inputs...)
+	for i, field := range inputs {
+		pair, component, ok := vectorComponent(field)
+		if !ok || component != 0 || !GridRelative(field.Section3.Sec3) {
+			continue
+		}
+		for j, other := range inputs {
+			if otherPair, otherComponent, ok := vectorComponent(other); ok && otherPair == pair && otherComponent == 1 {
+				u, v, err := RotateToEarth(field, other)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				rotated[i], rotated[j] = u, v
+				break
+			}
+		}
+	}
+	for _, field := range rotated {
+		if _, _, ok := vectorComponent(field); ok && GridRelative(field.Section3.Sec3) {
+			return nil, fmt.Errorf("%w: %s для %s", ErrGridRelative, field.Parameter.ShortName, name)
+		}
+	}
+	return rotated, nil
+}
+
+// deriveKey Поля, из которых вычисляются производные параметры: файл, срок, поверхность,
+// сетка и участник ансамбля
+type deriveKey struct {
+	source                 string
+	date, valid            int64
+	surfaceType            string
+	grid                   interface{}
+	points                 int
+	ensembleType, ensemble uint8
+}
+
+// levelKey Поле группы: параметр на уровне
+type levelKey struct {
+	parameter parameterKey
+	level     string
+}
+
+// deriveGroup Накопленные поля группы и уже вычисленные (или прочитанные) производные параметры
+type deriveGroup struct {
+	fields map[levelKey]*Table
+	done   map[string]map[string]bool
+}
+
+// DeriveStage Этап вычисления производных параметров Names. Входные поля проходят дальше без
+// изменений и накапливаются по файлу, сроку, поверхности и сетке. Как только в группе есть все
+// входы параметра, он вычисляется и передается дальше как обычное поле, а входы, которые больше
+// не нужны, освобождаются. Производные параметры могут быть входами других правил (RH для HEATX).
+// Параметр, уже прочитанный из файла, не вычисляется: отметки о прочитанных и вычисленных
+// параметрах хранятся до конца файла (FlushSource). Обрабатываются только мгновенные поля
+type DeriveStage struct {
+	Names []string
+
+	mu     sync.Mutex
+	groups map[deriveKey]*deriveGroup
+}
+
+// ParseDerive Создает этап по списку производных параметров через запятую ("WIND,WDIR,RH")
+func ParseDerive(spec string) (*DeriveStage, error) {
+	stage := &DeriveStage{}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := Derivations[name]; !ok {
+			return nil, fmt.Errorf("неизвестный производный параметр %q", name)
+		}
+		stage.Names = append(stage.Names, name)
+	}
+	return stage, nil
+}
+
+// relevant Сообщает, что поле является входом или результатом одного из правил этапа
+func (s *DeriveStage) relevant(field *Table) bool {
+	key := fieldKey(field)
+	for _, name := range s.Names {
+		derivation := Derivations[name]
+		if derivation.key() == key {
+			return true
+		}
+		for _, input := range derivation.Inputs {
+			if input.key() == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Process Пропускает поле дальше и добавляет производные параметры, для которых собраны все входы
+func (s *DeriveStage) Process(field *Table) []*Table {
+	if field.StatisticalProcess != ProcessNone || field.EnsembleProduct != "" || !s.relevant(field) {
+		return []*Table{field}
+	}
+	grid, _ := gridKey(field.Section3.Sec3.Definition)
+	key := deriveKey{
+		source:       field.source,
+		date:         field.Date.Unix(),
+		valid:        field.WindowEnd.Unix(),
+		surfaceType:  field.SurfaceType,
+		grid:         grid,
+		points:       len(field.Data),
+		ensembleType: field.EnsembleType,
+		ensemble:     field.EnsembleMember,
+	}
+	fields := []*Table{field}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.groups == nil {
+		s.groups = map[deriveKey]*deriveGroup{}
+	}
+	group := s.groups[key]
+	if group == nil {
+		group = &deriveGroup{fields: map[levelKey]*Table{}, done: map[string]map[string]bool{}}
+		s.groups[key] = group
+	}
+	group.add(s.Names, field)
+	for progress := true; progress; {
+		progress = false
+		for _, name := range s.Names {
+			derivation := Derivations[name]
+			for _, level := range group.levels(derivation, key.surfaceType) {
+				if group.done[name][level] {
+					continue
+				}
+				inputs, ok := group.inputs(derivation, level)
+				if !ok {
+					continue
+				}
+				group.markDone(name, level)
+				derived, err := Derive(name, inputs...)
+				if err != nil {
+					config.Logger.WithError(err).WithField("level", key.surfaceType+" "+level).Warn("Производный параметр не вычислен")
+					continue
+				}
+				group.add(s.Names, derived)
+				fields = append(fields, derived)
+				progress = true
+			}
+		}
+	}
+	group.prune(s.Names, key.surfaceType)
+	return fields
+}
+
+// add Добавляет поле в группу. Прочитанный или вычисленный производный параметр больше не вычисляется
+func (g *deriveGroup) add(names []string, field *Table) {
+	g.fields[levelKey{fieldKey(field), field.SurfaceValue}] = field
+	for _, name := range names {
+		if Derivations[name].key() == fieldKey(field) {
+			g.markDone(name, field.SurfaceValue)
+		}
+	}
+}
+
+func (g *deriveGroup) markDone(name string, level string) {
+	if g.done[name] == nil {
+		g.done[name] = map[string]bool{}
+	}
+	g.done[name][level] = true
+}
+
+// levels Возвращает уровни, на которых может быть вычислен параметр: фиксированный уровень правила
+// на подходящей поверхности или уровни имеющихся полей первого входа
+func (g *deriveGroup) levels(derivation Derivation, surfaceType string) []string {
+	first := derivation.Inputs[0]
+	if first.Level != "" {
+		if surfaceType != ReadSurfaceTypesUnits(first.Surface) {
+			return nil
+		}
+		return []string{first.Level}
+	}
+	var levels []string
+	for key := range g.fields {
+		if key.parameter == first.key() {
+			levels = append(levels, key.level)
+		}
+	}
+	return levels
+}
+
+// inputs Возвращает входные поля параметра на уровне level, если они все есть в группе
+func (g *deriveGroup) inputs(derivation Derivation, level string) ([]*Table, bool) {
+	inputs := make([]*Table, len(derivation.Inputs))
+	for i, input := range derivation.Inputs {
+		inputLevel := level
+		if input.Level != "" {
+			inputLevel = input.Level
+		}
+		field, ok := g.fields[levelKey{input.key(), inputLevel}]
+		if !ok {
+			return nil, false
+		}
+		inputs[i] = field
+	}
+	return inputs, true
+}
+
+// prune Освобождает поля, которые не нужны ни одному еще не вычисленному параметру
+func (g *deriveGroup) prune(names []string, surfaceType string) {
+	for key := range g.fields {
+		needed := false
+		for _, name := range names {
+			derivation := Derivations[name]
+			for _, input := range derivation.Inputs {
+				if input.key() != key.parameter {
+					continue
+				}
+				if input.Level != "" && (input.Level != key.level || surfaceType != ReadSurfaceTypesUnits(input.Surface)) {
+					continue
+				}
+				level := key.level
+				if input.Level != "" {
+					level = derivation.Inputs[0].Level
+				}
+				if !g.done[name][level] {
+					needed = true
+				}
+			}
+		}
+		if !needed {
+			delete(g.fields, key)
+		}
+	}
+}
+
+// FlushSource Освобождает поля файла source, для которых не нашлось всех входов, и отметки
+// о вычисленных параметрах
+func (s *DeriveStage) FlushSource(source string) []*Table {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.groups {
+		if key.source == source {
+			delete(s.groups, key)
+		}
+	}
+	return nil
+}
+
+// Flush Освобождает все накопленные поля
+func (s *DeriveStage) Flush() []*Table {
+	s.mu.Lock()
+	s.groups = nil
+	s.mu.Unlock()
+	return nil
+}
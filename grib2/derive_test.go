@@ -0,0 +1,277 @@
+package grib2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// derivedInput Возвращает поле параметра category.number дисциплины 0 на поверхности surface со значениями data
+func derivedInput(t *testing.T, category, number uint8, surface Surface, data Values) *Table {
+	t.Helper()
+	return testField{ni: 2, nj: 1, category: category, number: number, surface: &surface, data: data}.table(t)
+}
+
+// inUnit Пересчитывает значение value из единиц from в единицы to
+func inUnit(t *testing.T, value float64, from, to string) float64 {
+	t.Helper()
+	conversion, ok := Tables.Conversion(from, to)
+	if !ok {
+		t.Fatalf("нет пересчета из %q в %q", from, to)
+	}
+	return conversion.Apply(value)
+}
+
+func TestDerivationFormulas(t *testing.T) {
+	tests := []struct {
+		name        string
+		value, want float64
+	}{
+		{"упругость пара при 0 °C", vaporPressure(0), 6.112},
+		{"упругость пара при 20 °C", vaporPressure(20), 23.369471},
+		{"упругость пара при -10 °C", vaporPressure(-10), 2.867696},
+		{"индекс жары до 40 °F", heatIndex(35, 90), 35},
+		{"индекс жары по формуле Стедмана", heatIndex(70, 50), 69.05},
+		// Таблица NWS: 90 °F при 50 % — 95 °F
+		{"индекс жары по формуле Ротфуса", heatIndex(90, 50), 94.596941},
+		{"поправка на сухой воздух", heatIndex(100, 10), 94.122483},
+		{"поправка на влажный воздух", heatIndex(85, 90), 101.780804},
+		{"ветро-холодовой индекс выше 10 °C", windChill(11, 30), 11},
+		{"ветро-холодовой индекс при слабом ветре", windChill(-10, 4.8), -10},
+		// Таблица Environment Canada: -10 °C при 20 км/ч — -18
+		{"ветро-холодовой индекс", windChill(-10, 20), -17.860584},
+		{"направление ветра при штиле", Derivations["WDIR"].Compute([]float64{0, 0}), 0},
+		{"северный ветер", Derivations["WDIR"].Compute([]float64{0, -5}), 0},
+		{"западный ветер", Derivations["WDIR"].Compute([]float64{5, 0}), 270},
+		{"юго-западный ветер", Derivations["WDIR"].Compute([]float64{3, 3}), 225},
+		{"относительная влажность", Derivations["RH"].Compute([]float64{20, 10}), 52.511655},
+		{"перенасыщение", Derivations["RH"].Compute([]float64{10, 12}), 100},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if math.Abs(test.value-test.want) > 1e-6 {
+				t.Fatalf("%g, ожидалось %g", test.value, test.want)
+			}
+		})
+	}
+}
+
+func TestDerive(t *testing.T) {
+	isobaric := Surface{Type: surfaceIsobaric, Value: 85000}
+	u := derivedInput(t, 2, 2, isobaric, Values{3, MissingValue})
+	v := derivedInput(t, 2, 3, isobaric, Values{4, 1})
+	temperature := derivedInput(t, 0, 0, isobaric, Values{293.15, 283.15})
+	dewpoint := derivedInput(t, 0, 6, isobaric, Values{283.15, 283.15})
+	short := testField{ni: 3, nj: 1, category: 2, number: 3}.table(t)
+	tests := []struct {
+		name    string
+		derived string
+		inputs  []*Table
+		want    Values
+		err     bool
+	}{
+		{"скорость ветра", "WIND", []*Table{u, v}, Values{5, MissingValue}, false},
+		{"относительная влажность по значениям в K", "RH", []*Table{temperature, dewpoint}, Values{52.511655, 100}, false},
+		{"входы не по порядку", "WIND", []*Table{v, u}, nil, true},
+		{"не хватает входа", "WIND", []*Table{u}, nil, true},
+		{"разный размер", "WIND", []*Table{u, short}, nil, true},
+		{"неизвестный параметр", "NONE", []*Table{u, v}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := Derive(test.derived, test.inputs...)
+			if (err != nil) != test.err {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			for j, want := range test.want {
+				if IsMissing(want) != IsMissing(field.Data[j]) || !IsMissing(want) && math.Abs(field.Data[j]-want) > 1e-6 {
+					t.Fatalf("значения %v, ожидались %v", field.Data, test.want)
+				}
+			}
+			derivation := Derivations[test.derived]
+			if fieldKey(field) != derivation.key() || field.UUID == test.inputs[0].UUID || len(field.Data_int) != 0 {
+				t.Fatalf("параметр %s, идентификатор входа %v, %d кодов", field.Parameter.ShortName, field.UUID == test.inputs[0].UUID, len(field.Data_int))
+			}
+			if field.SurfaceValue != test.inputs[0].SurfaceValue || field.Date != test.inputs[0].Date {
+				t.Fatalf("уровень %s на %s, ожидался %s на %s", field.SurfaceValue, field.Date, test.inputs[0].SurfaceValue, test.inputs[0].Date)
+			}
+		})
+	}
+}
+
+func TestDeriveGridRelative(t *testing.T) {
+	grid := lambertGrid(0x38)
+	grid.Dx, grid.Dy = 500000000, 500000000
+	u := vectorField(t, 2, grid, []uint64{3, 3, 3, 3, 3, 3})
+	v := vectorField(t, 3, grid, []uint64{0, 0, 0, 0, 0, 0})
+	east, north, err := RotateToEarth(u, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	direction, err := Derive("WDIR", u, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GridRelative(direction.Section3.Sec3) {
+		t.Fatal("направление отнесено к осям сетки")
+	}
+	turned := false
+	for k := range direction.Data {
+		want := Derivations["WDIR"].Compute([]float64{east.Data[k], north.Data[k]})
+		want = inUnit(t, want, "deg true", direction.Parameter.Unit)
+		if math.Abs(direction.Data[k]-want) > 1e-6 {
+			t.Fatalf("узел %d: %g, ожидалось %g", k, direction.Data[k], want)
+		}
+		// Вдоль оси x сетки дует западный ветер только на центральном меридиане
+		turned = turned || math.Abs(direction.Data[k]-inUnit(t, 270, "deg true", direction.Parameter.Unit)) > 0.5
+	}
+	if !turned {
+		t.Fatal("компоненты не повернуты")
+	}
+	if u.Data[0] != 3 || !GridRelative(u.Section3.Sec3) {
+		t.Fatal("изменено входное поле")
+	}
+
+	// Компонента без парной не поворачивается и не используется
+	Derivations["UABS"] = Derivation{
+		Discipline: 0, Category: 2, Number: 1, Unit: "m s-1",
+		Inputs:  []DerivedInput{{Discipline: 0, Category: 2, Number: 2, Unit: "m s-1"}},
+		Compute: func(values []float64) float64 { return math.Abs(values[0]) },
+	}
+	defer delete(Derivations, "UABS")
+	if _, err := Derive("UABS", u); !errors.Is(err, ErrGridRelative) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrGridRelative)
+	}
+}
+
+func TestParseDerive(t *testing.T) {
+	stage, err := ParseDerive(" WIND,, WDIR ,RH,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stage.Names) != 3 || stage.Names[0] != "WIND" || stage.Names[1] != "WDIR" || stage.Names[2] != "RH" {
+		t.Fatalf("параметры %v", stage.Names)
+	}
+	if _, err := ParseDerive("WIND,wind"); err == nil {
+		t.Fatal("разобран неизвестный параметр")
+	}
+}
+
+// process Передает поля этапу по очереди и возвращает все поля на его выходе
+func process(stage Stage, fields ...*Table) []*Table {
+	var out []*Table
+	for _, field := range fields {
+		out = append(out, stage.Process(field)...)
+	}
+	return out
+}
+
+// checkParameters Проверяет, что параметры полей совпадают с want по порядку
+func checkParameters(t *testing.T, fields []*Table, want ...parameterKey) {
+	t.Helper()
+	if len(fields) != len(want) {
+		t.Fatalf("передано %d полей, ожидалось %d", len(fields), len(want))
+	}
+	for k, field := range fields {
+		if fieldKey(field) != want[k] {
+			t.Fatalf("поле %d: %s, ожидалось %d.%d.%d", k, field.Parameter.ShortName, want[k].Discipline, want[k].Category, want[k].Number)
+		}
+	}
+}
+
+// held Возвращает количество полей, задержанных этапом
+func held(stage *DeriveStage) int {
+	count := 0
+	for _, group := range stage.groups {
+		count += len(group.fields)
+	}
+	return count
+}
+
+func TestDeriveStage(t *testing.T) {
+	isobaric := Surface{Type: surfaceIsobaric, Value: 85000}
+	uKey, vKey := parameterKey{0, 2, 2}, parameterKey{0, 2, 3}
+	windKey, directionKey := Derivations["WIND"].key(), Derivations["WDIR"].key()
+
+	t.Run("компоненты ветра", func(t *testing.T) {
+		stage := &DeriveStage{Names: []string{"WIND", "WDIR"}}
+		u := derivedInput(t, 2, 2, isobaric, Values{3, 0})
+		v := derivedInput(t, 2, 3, isobaric, Values{4, 0})
+		checkParameters(t, stage.Process(u), uKey)
+		fields := stage.Process(v)
+		checkParameters(t, fields, vKey, windKey, directionKey)
+		if fields[1].Data[0] != 5 || fields[1].Data[1] != 0 {
+			t.Fatalf("скорость ветра %v", fields[1].Data)
+		}
+		if count := held(stage); count != 0 {
+			t.Fatalf("не освобождено %d полей", count)
+		}
+		// Повтор компоненты ничего не вычисляет повторно
+		checkParameters(t, stage.Process(v), vKey)
+	})
+
+	t.Run("параметр прочитан из файла", func(t *testing.T) {
+		stage := &DeriveStage{Names: []string{"WIND"}}
+		fields := process(stage,
+			derivedInput(t, 2, 1, isobaric, Values{5, 0}),
+			derivedInput(t, 2, 2, isobaric, Values{3, 0}),
+			derivedInput(t, 2, 3, isobaric, Values{4, 0}))
+		checkParameters(t, fields, windKey, uKey, vKey)
+		if count := held(stage); count != 0 {
+			t.Fatalf("не освобождено %d полей", count)
+		}
+	})
+
+	t.Run("цепочка правил у земли", func(t *testing.T) {
+		stage := &DeriveStage{Names: []string{"WIND", "RH", "HEATX", "WCF"}}
+		at2m, at10m := Surface{Type: 103, Value: 2}, Surface{Type: 103, Value: 10}
+		// Ветер 20 км/ч
+		speed := 20 / 3.6
+		temperature := derivedInput(t, 0, 0, at2m, Values{263.15, 303.15})
+		fields := process(stage,
+			temperature,
+			derivedInput(t, 0, 6, at2m, Values{261.15, 293.15}))
+		rhKey, heatKey, chillKey := Derivations["RH"].key(), Derivations["HEATX"].key(), Derivations["WCF"].key()
+		checkParameters(t, fields, parameterKey{0, 0, 0}, parameterKey{0, 0, 6}, rhKey, heatKey)
+		if count := held(stage); count != 1 {
+			t.Fatalf("задержано %d полей, ожидалась только температура для WCF", count)
+		}
+		rh := fields[2].Data[1]
+		want := inUnit(t, heatIndex(86, inUnit(t, rh, fields[2].Parameter.Unit, "%")), "°F", fields[3].Parameter.Unit)
+		if math.Abs(fields[3].Data[1]-want) > 1e-6 || fields[3].SurfaceValue != temperature.SurfaceValue {
+			t.Fatalf("индекс жары %g на %s, ожидалось %g на %s", fields[3].Data[1], fields[3].SurfaceValue, want, temperature.SurfaceValue)
+		}
+
+		fields = process(stage,
+			derivedInput(t, 2, 2, at10m, Values{speed * 0.6, 0}),
+			derivedInput(t, 2, 3, at10m, Values{speed * 0.8, 0}))
+		checkParameters(t, fields, uKey, vKey, windKey, chillKey)
+		want = inUnit(t, windChill(-10, 20), "°C", fields[3].Parameter.Unit)
+		if math.Abs(fields[3].Data[0]-want) > 1e-6 || fields[3].SurfaceValue != temperature.SurfaceValue {
+			t.Fatalf("ветро-холодовой индекс %g на %s, ожидалось %g на %s", fields[3].Data[0], fields[3].SurfaceValue, want, temperature.SurfaceValue)
+		}
+		if count := held(stage); count != 0 {
+			t.Fatalf("не освобождено %d полей", count)
+		}
+	})
+
+	t.Run("освобождение по файлу", func(t *testing.T) {
+		stage := &DeriveStage{Names: []string{"WIND"}}
+		u := derivedInput(t, 2, 2, isobaric, Values{3, 0})
+		v := derivedInput(t, 2, 3, isobaric, Values{4, 0})
+		stage.Process(u)
+		if fields := stage.FlushSource("other"); len(fields) != 0 || len(stage.groups) != 1 {
+			t.Fatalf("передано %d полей, осталось %d групп", len(fields), len(stage.groups))
+		}
+		if fields := stage.FlushSource(u.source); len(fields) != 0 || len(stage.groups) != 0 {
+			t.Fatalf("передано %d полей, осталось %d групп", len(fields), len(stage.groups))
+		}
+		checkParameters(t, stage.Process(v), vKey)
+		if fields := stage.Flush(); len(fields) != 0 || len(stage.groups) != 0 {
+			t.Fatalf("передано %d полей, осталось %d групп", len(fields), len(stage.groups))
+		}
+	})
+}
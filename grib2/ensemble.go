@@ -0,0 +1,295 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// EnsembleNone Тип ансамбля полей, не являющихся участниками ансамбля
+const EnsembleNone uint8 = 255
+
+// Ансамблевые характеристики, вычисляемые по участникам
+const (
+	EnsembleMean   = "mean"   // Среднее
+	EnsembleSpread = "spread" // Разброс (стандартное отклонение)
+	EnsembleMin    = "min"    // Минимум
+	EnsembleMax    = "max"    // Максимум
+	EnsembleProb   = "prob"   // Вероятность превышения порога, %
+)
+
+// ensembleKey Поля одного ансамбля: параметр, поверхность, срок и интервал прогноза, сетка
+type ensembleKey struct {
+	date, windowStart, windowEnd int64
+	discipline, category, number uint8
+	surfaceType, surfaceValue    string
+	forecastTime                 int32
+	process                      uint8
+	grid                         interface{}
+	points                       int
+}
+
+// EnsembleStats Этап вычисления ансамблевых характеристик. Участники ансамбля проходят дальше без
+// изменений и накапливаются по параметру, уровню, сроку и сетке. Значения участников не хранятся:
+// в каждой точке накапливаются количество значений, среднее, сумма квадратов отклонений, минимум,
+// максимум и число превышений порогов. Как только прочитаны все участники (их количество указано
+// в шаблоне 4.1), выдаются характеристики Products и вероятности превышения порогов Thresholds,
+// заданных для коротких названий параметров. Неполные ансамбли обрабатываются при завершении этапа
+type EnsembleStats struct {
+	Products   []string
+	Thresholds map[string][]float64
+
+	mu     sync.Mutex
+	groups map[ensembleKey]*ensembleAccumulator
+}
+
+// ParseEnsembleStats Создает этап по списку характеристик через запятую ("mean,spread,min,max,prob")
+// и порогам вида "TMP:273.15/283.15;APCP:1/10"
+func ParseEnsembleStats(products string, thresholds string) (*EnsembleStats, error) {
+	stage := &EnsembleStats{Thresholds: map[string][]float64{}}
+	for _, product := range strings.Split(products, ",") {
+		product = strings.TrimSpace(product)
+		switch product {
+		case "":
+			continue
+		case EnsembleMean, EnsembleSpread, EnsembleMin, EnsembleMax, EnsembleProb:
+			stage.Products = append(stage.Products, product)
+		default:
+			return nil, fmt.Errorf("неизвестная ансамблевая характеристика %q", product)
+		}
+	}
+	for _, part := range strings.Split(thresholds, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, values, ok := strings.Cut(part, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("пороги %q: ожидается параметр:порог/порог", part)
+		}
+		for _, value := range strings.Split(values, "/") {
+			threshold, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, fmt.Errorf("пороги %q: %w", part, err)
+			}
+			stage.Thresholds[strings.TrimSpace(name)] = append(stage.Thresholds[strings.TrimSpace(name)], threshold)
+		}
+	}
+	return stage, nil
+}
+
+// Process Пропускает поле дальше и, если оно завершает ансамбль, добавляет его характеристики
+func (e *EnsembleStats) Process(field *Table) []*Table {
+	// Категориальные параметры не усредняются
+	if field.EnsembleType == EnsembleNone || field.EnsembleProduct != "" || field.Parameter.Categorical() {
+		return []*Table{field}
+	}
+	grid, _ := gridKey(field.Section3.Sec3.Definition)
+	key := ensembleKey{
+		date:         field.Date.Unix(),
+		windowStart:  field.WindowStart.Unix(),
+		windowEnd:    field.WindowEnd.Unix(),
+		discipline:   field.Parameter.Discipline,
+		category:     field.Parameter.Category,
+		number:       field.Parameter.Number,
+		surfaceType:  field.SurfaceType,
+		surfaceValue: field.SurfaceValue,
+		forecastTime: field.ForecastTime,
+		process:      field.StatisticalProcess,
+		grid:         grid,
+		points:       len(field.Data),
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.groups == nil {
+		e.groups = map[ensembleKey]*ensembleAccumulator{}
+	}
+	group := e.groups[key]
+	if group == nil {
+		group = newEnsembleAccumulator(len(field.Data), e.Thresholds[field.Parameter.ShortName])
+		e.groups[key] = group
+	}
+	// Накопленные значения нельзя исключить, поэтому повторно прочитанный участник пропускается
+	if !group.add(field) {
+		config.Logger.WithField("parameter", field.Parameter.ShortName).WithField("member", field.EnsembleMember).Warn("Участник ансамбля прочитан повторно и не учитывается")
+		return []*Table{field}
+	}
+	if field.EnsembleSize == 0 || len(group.members) < int(field.EnsembleSize) {
+		return []*Table{field}
+	}
+	delete(e.groups, key)
+	return append([]*Table{field}, group.products(e.Products)...)
+}
+
+// Flush Вычисляет характеристики неполных ансамблей из двух и более участников
+func (e *EnsembleStats) Flush() []*Table {
+	e.mu.Lock()
+	groups := e.groups
+	e.groups = nil
+	e.mu.Unlock()
+	var fields []*Table
+	for _, group := range groups {
+		first := group.template
+		if len(group.members) < 2 {
+			config.Logger.WithField("parameter", first.Parameter.ShortName).WithField("level", first.SurfaceType+" "+first.SurfaceValue).Warn("Ансамбль из одного участника, характеристики не вычисляются")
+			continue
+		}
+		config.Logger.WithField("parameter", first.Parameter.ShortName).WithField("level", first.SurfaceType+" "+first.SurfaceValue).WithField("members", len(group.members)).WithField("size", first.EnsembleSize).Warn("Неполный ансамбль")
+		fields = append(fields, group.products(e.Products)...)
+	}
+	return fields
+}
+
+// ensembleAccumulator Характеристики ансамбля, накопленные по прочитанным участникам в каждой точке.
+// Среднее и сумма квадратов отклонений от него обновляются по Уэлфорду, чтобы разброс не терял
+// точность на полях с большим средним
+type ensembleAccumulator struct {
+	template   *Table         // Метаданные участника с наименьшим номером без значений
+	members    map[uint8]bool // Номера учтенных участников
+	count      []int          // Количество участников со значением
+	mean, m2   []float64      // Среднее и сумма квадратов отклонений от среднего
+	low, high  []float64      // Минимум и максимум
+	thresholds []float64      // Пороги вероятности превышения
+	exceed     [][]int        // Количество превышений каждого порога
+}
+
+// newEnsembleAccumulator Создает пустые накопители для полей из points точек
+func newEnsembleAccumulator(points int, thresholds []float64) *ensembleAccumulator {
+	group := &ensembleAccumulator{
+		members:    map[uint8]bool{},
+		count:      make([]int, points),
+		mean:       make([]float64, points),
+		m2:         make([]float64, points),
+		low:        make([]float64, points),
+		high:       make([]float64, points),
+		thresholds: thresholds,
+		exceed:     make([][]int, len(thresholds)),
+	}
+	for j := range group.low {
+		group.low[j], group.high[j] = math.Inf(1), math.Inf(-1)
+	}
+	for i := range group.exceed {
+		group.exceed[i] = make([]int, points)
+	}
+	return group
+}
+
+// add Учитывает значения участника. Возвращает false, если участник с тем же номером уже учтен
+func (group *ensembleAccumulator) add(member *Table) bool {
+	if group.members[member.EnsembleMember] {
+		return false
+	}
+	group.members[member.EnsembleMember] = true
+	if group.template == nil || member.EnsembleMember < group.template.EnsembleMember {
+		template := *member
+		template.Data, template.Data_int, template.Legend, template.Statistics = nil, nil, nil, nil
+		group.template = &template
+	}
+	for j, value := range member.Data {
+		if IsMissing(value) {
+			continue
+		}
+		group.count[j]++
+		delta := value - group.mean[j]
+		group.mean[j] += delta / float64(group.count[j])
+		group.m2[j] += delta * (value - group.mean[j])
+		group.low[j] = math.Min(group.low[j], value)
+		group.high[j] = math.Max(group.high[j], value)
+		for i, threshold := range group.thresholds {
+			if value > threshold {
+				group.exceed[i][j]++
+			}
+		}
+	}
+	return true
+}
+
+// products Возвращает поля характеристик products по накопленным значениям. Точка без значений
+// у всех участников остается пропущенной
+func (group *ensembleAccumulator) products(products []string) []*Table {
+	first := group.template
+	size := len(group.members)
+	var fields []*Table
+	for _, product := range products {
+		if product == EnsembleProb {
+			for i, threshold := range group.thresholds {
+				data := make(Values, len(group.count))
+				for j, valid := range group.count {
+					data[j] = MissingValue
+					if valid > 0 {
+						data[j] = 100 * float64(group.exceed[i][j]) / float64(valid)
+					}
+				}
+				field := ensembleField(first, data, fmt.Sprintf("%s>%s", EnsembleProb, strconv.FormatFloat(threshold, 'f', -1, 64)), size)
+				field.Parameter.Name = fmt.Sprintf("Probability of %s > %s", first.Parameter.Name, strconv.FormatFloat(threshold, 'f', -1, 64))
+				field.Parameter.Unit = "%"
+				fields = append(fields, field)
+			}
+			continue
+		}
+		data := make(Values, len(group.count))
+		for j, valid := range group.count {
+			if valid == 0 {
+				data[j] = MissingValue
+				continue
+			}
+			switch product {
+			case EnsembleMean:
+				data[j] = group.mean[j]
+			case EnsembleSpread:
+				data[j] = math.Sqrt(group.m2[j] / float64(valid))
+			case EnsembleMin:
+				data[j] = group.low[j]
+			case EnsembleMax:
+				data[j] = group.high[j]
+			}
+		}
+		fields = append(fields, ensembleField(first, data, product, size))
+	}
+	return fields
+}
+
+// EnsembleStatistics Вычисляет по участникам ансамбля поля характеристик products. Характеристика
+// prob дает по одному полю вероятности превышения (в процентах) на каждый порог из thresholds.
+// В каждой точке учитываются только участники со значением, точка без значений у всех
+// участников остается пропущенной. Участники должны относиться к одному параметру, поверхности
+// и сроку и иметь одинаковый размер
+func EnsembleStatistics(members []*Table, products []string, thresholds []float64) ([]*Table, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	first := members[0]
+	for _, member := range members {
+		if member.Parameter.Discipline != first.Parameter.Discipline || member.Parameter.Category != first.Parameter.Category ||
+			member.Parameter.Number != first.Parameter.Number || member.SurfaceType != first.SurfaceType || member.SurfaceValue != first.SurfaceValue {
+			return nil, fmt.Errorf("участники %s и %s относятся к разным параметрам или поверхностям", first.Parameter.ShortName, member.Parameter.ShortName)
+		}
+		if !member.Date.Equal(first.Date) || !member.WindowEnd.Equal(first.WindowEnd) {
+			return nil, fmt.Errorf("участники %s относятся к разным срокам прогноза", first.Parameter.ShortName)
+		}
+		if len(member.Data) != len(first.Data) {
+			return nil, fmt.Errorf("участники %s имеют разный размер: %d и %d", first.Parameter.ShortName, len(first.Data), len(member.Data))
+		}
+	}
+	group := newEnsembleAccumulator(len(first.Data), thresholds)
+	for _, member := range members {
+		if !group.add(member) {
+			return nil, fmt.Errorf("участник %d ансамбля %s указан дважды", member.EnsembleMember, first.Parameter.ShortName)
+		}
+	}
+	return group.products(products), nil
+}
+
+// ensembleField Создает поле ансамблевой характеристики по метаданным участника
+func ensembleField(member *Table, data Values, product string, size int) *Table {
+	field := member.derive(data)
+	field.EnsembleType = EnsembleNone
+	field.EnsembleMember = 0
+	field.EnsembleSize = uint8(size)
+	field.EnsembleProduct = product
+	return field
+}
@@ -0,0 +1,116 @@
+package grib2
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ensembleMember Возвращает участника number ансамбля из size участников со значениями values
+// на сетке 2x1, сдвинутой на shift микроградусов по долготе
+func ensembleMember(t *testing.T, number uint8, size uint8, shift int32, values []uint64) *Table {
+	t.Helper()
+	field := testField{ni: 2, nj: 1, codes: values}.table(t)
+	grid := field.Section3.Sec3.Definition.(*Grid0)
+	grid.Lo1 += shift
+	grid.Lo2 += shift
+	field.EnsembleType, field.EnsembleMember, field.EnsembleSize = 3, number, size
+	return field
+}
+
+func TestEnsembleGrids(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []*Table
+		means   []Values
+	}{
+		{
+			"одна сетка",
+			[]*Table{ensembleMember(t, 0, 2, 0, []uint64{0, 10}), ensembleMember(t, 1, 2, 0, []uint64{2, 20})},
+			[]Values{{1, 15}},
+		},
+		{
+			"разные сетки одного размера",
+			[]*Table{
+				ensembleMember(t, 0, 2, 0, []uint64{0, 10}),
+				ensembleMember(t, 0, 2, 500000, []uint64{100, 100}),
+				ensembleMember(t, 1, 2, 0, []uint64{2, 20}),
+				ensembleMember(t, 1, 2, 500000, []uint64{200, 200}),
+			},
+			[]Values{{1, 15}, {150, 150}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stage := &EnsembleStats{Products: []string{EnsembleMean}}
+			var means []Values
+			for _, member := range test.members {
+				for _, field := range stage.Process(member) {
+					if field.EnsembleProduct == EnsembleMean {
+						means = append(means, field.Data)
+					}
+				}
+			}
+			if len(stage.Flush()) != 0 {
+				t.Fatal("остались неполные ансамбли")
+			}
+			if !reflect.DeepEqual(means, test.means) {
+				t.Fatalf("средние %v, ожидались %v", means, test.means)
+			}
+		})
+	}
+}
+
+func TestEnsembleStatistics(t *testing.T) {
+	nan := MissingValue
+	member := func(number uint8, data Values) *Table {
+		field := testField{ni: 3, nj: 1, data: data}.table(t)
+		field.EnsembleType, field.EnsembleMember, field.EnsembleSize = 3, number, 3
+		return field
+	}
+	// Среднее 1e8 при разбросе 1: однопроходная формула теряет разброс из-за вычитания близких чисел
+	members := []*Table{
+		member(2, Values{1e8 + 1, 5, nan}),
+		member(0, Values{1e8 - 1, 1, nan}),
+		member(1, Values{1e8, nan, nan}),
+	}
+	stage := &EnsembleStats{Products: []string{EnsembleMean, EnsembleSpread, EnsembleMin, EnsembleMax, EnsembleProb}, Thresholds: map[string][]float64{"TMP": {2}}}
+	var products []*Table
+	for i, field := range members {
+		out := stage.Process(field)
+		if out[0] != field {
+			t.Fatal("участник не пропущен дальше")
+		}
+		if i == 0 && len(stage.Process(member(2, Values{0, 0, 0}))) != 1 {
+			t.Fatal("повторный участник завершил ансамбль")
+		}
+		products = append(products, out[1:]...)
+	}
+	if len(stage.groups) != 0 || len(stage.Flush()) != 0 {
+		t.Fatal("ансамбль не завершен")
+	}
+	want := map[string]Values{
+		EnsembleMean:   {1e8, 3, nan},
+		EnsembleSpread: {math.Sqrt(2.0 / 3), 2, nan},
+		EnsembleMin:    {1e8 - 1, 1, nan},
+		EnsembleMax:    {1e8 + 1, 5, nan},
+		"prob>2":       {100, 50, nan},
+	}
+	if len(products) != len(want) {
+		t.Fatalf("получено %d характеристик, ожидалось %d", len(products), len(want))
+	}
+	for _, field := range products {
+		expected, ok := want[field.EnsembleProduct]
+		if !ok {
+			t.Fatalf("лишняя характеристика %s", field.EnsembleProduct)
+		}
+		for j := range expected {
+			if IsMissing(expected[j]) != IsMissing(field.Data[j]) || !IsMissing(expected[j]) && math.Abs(field.Data[j]-expected[j]) > 1e-9 {
+				t.Fatalf("%s: %v, ожидалось %v", field.EnsembleProduct, field.Data, expected)
+			}
+		}
+		if field.EnsembleMember != 0 || field.EnsembleSize != 3 || field.EnsembleType != EnsembleNone {
+			t.Fatalf("%s: метаданные участника %d из %d", field.EnsembleProduct, field.EnsembleMember, field.EnsembleSize)
+		}
+	}
+}
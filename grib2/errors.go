@@ -0,0 +1,64 @@
+package grib2
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Виды ошибок декодирования. Проверяются через errors.Is на ошибке, возвращенной парсером
+var (
+	// ErrTruncatedMessage Сообщение или секция закончились раньше, чем указано в их длине
+	ErrTruncatedMessage = errors.New("сообщение обрезано")
+	// ErrUnsupportedTemplate Шаблон сетки, продукта или представления данных не поддерживается
+	ErrUnsupportedTemplate = errors.New("неподдерживаемый шаблон")
+	// ErrBadEndMarker В конце сообщения нет маркера "7777"
+	ErrBadEndMarker = errors.New("некорректный маркер конца сообщения")
+	// ErrLengthMismatch Длины секций не согласуются между собой или с длиной сообщения из Секции 0
+	ErrLengthMismatch = errors.New("несовпадение длины")
+	// ErrCorruptData Данные сообщения не удалось разобрать
+	ErrCorruptData = errors.New("поврежденные данные")
+	// ErrLimitExceeded Размер из заголовка сообщения превышает ограничения DecodeLimits
+	ErrLimitExceeded = errors.New("превышено ограничение")
+	// ErrDecoderPanic Декодер аварийно завершился на сообщении. Всегда сопровождается ErrCorruptData
+	// и означает ошибку в самом декодере, а не только в данных
+	ErrDecoderPanic = errors.New("паника декодера")
+)
+
+// MessageError Ошибка декодирования сообщения с указанием места ее возникновения
+type MessageError struct {
+	File    string // Путь к файлу
+	Message int    // Порядковый номер сообщения в файле, начиная с 0
+	Section int    // Номер секции, -1 если ошибка не относится к конкретной секции
+	Offset  int64  // Смещение в байтах от начала файла
+	Err     error
+}
+
+func (e *MessageError) Error() string {
+	place := fmt.Sprintf("%s: сообщение %d, смещение %d", e.File, e.Message, e.Offset)
+	if e.Section >= 0 {
+		place += fmt.Sprintf(", секция %d", e.Section)
+	}
+	return place + ": " + e.Err.Error()
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// sectionError Оборачивает ошибку секции, смещение считается от начала тела сообщения (после Секции 0)
+func sectionError(section uint8, offset int64, err error) error {
+	return &MessageError{Section: int(section), Offset: offset, Err: err}
+}
+
+// locate Дополняет ошибку декодирования данными о файле и сообщении
+func locate(err error, file string, raw *rawMessage) error {
+	var msgErr *MessageError
+	if errors.As(err, &msgErr) {
+		located := *msgErr
+		located.File = file
+		located.Message = raw.Index
+		located.Offset += raw.Offset + 16
+		return &located
+	}
+	return &MessageError{File: file, Message: raw.Index, Section: -1, Offset: raw.Offset, Err: err}
+}
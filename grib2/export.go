@@ -33,7 +33,7 @@ func SaveMessage(savePath string, bufChannel chan *Message) error {
 			config.Logger.WithError(err).Error("Ошибка создания директории")
 			return err
 		}
-		filename := prefix + "/" + ReadProductDisciplineCategoryParameters(uint16(ms.Section0.Discipline), ms.Section4.ProductDefinitionTemplate.ParameterCategory, ms.Section4.ProductDefinitionTemplate.ParameterNumber) + "_" + ReadSurfaceTypesUnits(int(ms.Section4.ProductDefinitionTemplate.FirstSurface.Type)) + "_" + fmt.Sprintf("%dm", ms.Section4.ProductDefinitionTemplate.FirstSurface.Value)
+		filename := prefix + "/" + LookupParameter(ms.Section1, ms.Section0.Discipline, ms.Section4.ProductDefinitionTemplate.ParameterCategory, ms.Section4.ProductDefinitionTemplate.ParameterNumber).ShortName + "_" + ReadSurfaceTypesUnits(int(ms.Section4.ProductDefinitionTemplate.FirstSurface.Type)) + "_" + fmt.Sprintf("%dm", ms.Section4.ProductDefinitionTemplate.FirstSurface.Value)
 		err = ioutil.WriteFile(filename+".json", jsonData, 0644)
 		if err != nil {
 			config.Logger.WithError(err).Error("Ошибка записи файла")
@@ -56,13 +56,18 @@ func SaveJson(savePath string, bufChannel chan *Table) error {
 			config.Logger.WithError(err).Error("Ошибка формирования json")
 			return err
 		}
-		path := savePath + "/" + fmt.Sprint(ms.Date.Year(), "-", ms.Date.Month(), "-", ms.Date.Day(), "_", ms.Date.Hour(), "_", ms.Date.Minute(), "_", ms.Date.Second()) + "/" + fmt.Sprint(ms.ForecastTime)
-		prefix, err := createFolder(path)
+		prefix, err := createFolder(tableFolder(savePath, ms))
 		if err != nil {
 			config.Logger.WithError(err).Error("Ошибка создания директории")
 			return err
 		}
-		filename := prefix + "/" + ms.Param + "_" + ms.SurfaceType + "_" + ms.SurfaceValue
+		filename := prefix + "/" + ms.Parameter.ShortName + "_" + ms.SurfaceType + "_" + ms.SurfaceValue
+		// Участники ансамбля и ансамблевые характеристики одного параметра сохраняются в разные файлы
+		if ms.EnsembleProduct != "" {
+			filename += "_" + ms.EnsembleProduct
+		} else if ms.EnsembleType != EnsembleNone {
+			filename += fmt.Sprintf("_m%d", ms.EnsembleMember)
+		}
 		err = ioutil.WriteFile(filename+".json", jsonData, 0644)
 		if err != nil {
 			config.Logger.WithError(err).Error("Ошибка записи файла")
@@ -71,6 +76,16 @@ func SaveJson(savePath string, bufChannel chan *Table) error {
 	}
 }
 
+// tableFolder Возвращает папку json-файлов поля: срок прогноза и шаг
+func tableFolder(savePath string, ms *Table) string {
+	// Поля с интервалом статистической обработки, начинающимся в одно время, различаются концом интервала
+	step := fmt.Sprint(ms.ForecastTime)
+	if ms.StatisticalProcess != ProcessNone {
+		step = formatStep(ms.WindowStart.Sub(ms.Date)) + "-" + formatStep(ms.WindowEnd.Sub(ms.Date))
+	}
+	return savePath + "/" + fmt.Sprint(ms.Date.Year(), "-", ms.Date.Month(), "-", ms.Date.Day(), "_", ms.Date.Hour(), "_", ms.Date.Minute(), "_", ms.Date.Second()) + "/" + step
+}
+
 // createFolder Создает папки для сохранения json-файлов
 func createFolder(path string) (string, error) {
 	err := os.MkdirAll(path, 0644)
@@ -85,7 +100,7 @@ var tableName string
 
 // SaveDB Сохраняет расшифрованные грибы в базу данных PostgreSQL
 func SaveDB(bufChannel chan *Table) error {
-	columnNames := []string{"id", "grib_datetime", "forecast_time", "parameter", "surface_type", "surface_value", "grid_properties", "grib_data", "grib_data_int"}
+	columnNames := []string{"id", "grib_datetime", "forecast_time", "parameter", "surface_type", "surface_value", "grid_properties", "grib_data", "grib_data_int", "missing_count", "reference_value", "binary_scale", "decimal_scale", "discipline", "category", "number", "unit", "short_name", "standard_name", "legend", "window_start", "window_end", "statistical_process", "step_type", "ensemble_type", "ensemble_member", "ensemble_size", "ensemble_product", "interpolated", "value_min", "value_max", "value_mean", "value_std", "value_percentiles"}
 	bc := make(chan *Table, 100)
 	copySource := &MessageCopySource{
 		Messages: bc,
@@ -130,13 +145,64 @@ func SaveDB(bufChannel chan *Table) error {
 type Table struct {
 	UUID         uuid.UUID
 	Date         time.Time
-	ForecastTime uint32
-	Param        string
+	ForecastTime int32
+	Parameter    Parameter
 	SurfaceType  string
 	SurfaceValue string
 	Section3     S3
-	Data         []float64
-	Data_int     []int
+	Data         Values
+	Data_int     []int32 // Упакованные коды значений, MissingCode для точек без данных
+	MissingCount int     // Количество точек без данных
+	Reference    float32 // Опорное значение R
+	BinaryScale  int16   // Двоичный масштабный множитель E
+	DecimalScale int16   // Десятичный масштабный множитель D
+	// Расшифровка кодов категориального параметра: значение -> описание из кодовой таблицы
+	Legend             map[string]string `json:",omitempty"`
+	WindowStart        time.Time         // Начало интервала статистической обработки
+	WindowEnd          time.Time         // Конец интервала статистической обработки
+	StatisticalProcess uint8             // Тип статистической обработки (Code table 4.10), ProcessNone для мгновенных полей
+	StepType           string            // Название типа обработки в ecCodes: instant, accum, avg, max, min
+	EnsembleType       uint8             // Тип ансамблевого прогноза (Code table 4.6), EnsembleNone для детерминированных полей
+	EnsembleMember     uint8             // Номер участника ансамбля, 0 для контрольного прогноза
+	EnsembleSize       uint8             // Количество участников ансамбля
+	EnsembleProduct    string            // Ансамблевая характеристика производного поля: mean, spread, min, max, prob>порог
+	Interpolated       bool              // Поле получено интерполяцией по времени между шагами прогноза
+	Statistics         *Statistics       `json:",omitempty"` // Минимум, максимум, среднее, стандартное отклонение и процентили значений
+	source             string            // Файл, из которого прочитано поле
+	timeUnit           uint8             // Единица времени прогноза ForecastTime (Code table 4.4)
+	surface            Surface           // Первая поверхность из Секции 4
+	section1           Section1          // Центр и версии таблиц из Секции 1 для описания кодов
+}
+
+// tables Возвращает Секцию 1 сообщения, из которого прочитано поле, для поиска в кодовых таблицах.
+// Для полей, созданных не из сообщения, используются все версии мастер-таблиц ВМО
+func (t *Table) tables() Section1 {
+	if t.section1 == (Section1{}) {
+		return defaultSection1
+	}
+	return t.section1
+}
+
+// derive Создает поле с теми же метаданными, новыми значениями и новым UUID. Упакованные коды
+// и параметры упаковки к новым значениям не относятся и не копируются. Преобразования, которые
+// заменяют поле, а не добавляют новое (Convert, RotateToEarth), возвращают полю прежний UUID
+func (t *Table) derive(data Values) *Table {
+	field := *t
+	field.UUID = uuid.New()
+	field.Data = data
+	field.Data_int = []int32{}
+	field.Reference = 0
+	field.BinaryScale = 0
+	field.DecimalScale = 0
+	field.MissingCount = 0
+	for _, value := range data {
+		if IsMissing(value) {
+			field.MissingCount++
+		}
+	}
+	field.Legend = t.Parameter.Legend(t.tables(), data)
+	field.Statistics = ComputeStatistics(data, Percentiles)
+	return &field
 }
 
 // Структура необходимая для потоковой записи в PostgreSQL
@@ -166,7 +232,11 @@ func (s *MessageCopySource) Next() bool {
 func (s *MessageCopySource) Values() ([]interface{}, error) {
 	// Возвращает значения для текущего сообщения из канала Messages
 	message := s.Value
-	return []interface{}{message.UUID, message.Date, message.ForecastTime, message.Param, message.SurfaceType, message.SurfaceValue, message.Section3, message.Data, message.Data_int}, nil
+	values := []interface{}{message.UUID, message.Date, message.ForecastTime, message.Parameter.Name, message.SurfaceType, message.SurfaceValue, message.Section3, message.Data.Column(), message.Data_int, message.MissingCount, message.Reference, message.BinaryScale, message.DecimalScale,
+		int16(message.Parameter.Discipline), int16(message.Parameter.Category), int16(message.Parameter.Number), message.Parameter.Unit, message.Parameter.ShortName, message.Parameter.StandardName, message.Legend,
+		message.WindowStart, message.WindowEnd, int16(message.StatisticalProcess), message.StepType,
+		int16(message.EnsembleType), int16(message.EnsembleMember), int16(message.EnsembleSize), message.EnsembleProduct, message.Interpolated}
+	return append(values, statisticsValues(message.Statistics)...), nil
 }
 
 // Err Метод структуры MessageCopySources обрабатывающий ошибки записи в поток
@@ -0,0 +1,220 @@
+package grib2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IngestRules Правила отбора сообщений при загрузке файлов
+var IngestRules Rules
+
+// filterOperators Операторы условий фильтра. Двухсимвольные проверяются первыми
+var filterOperators = []string{"!=", "<=", ">=", "=", "<", ">"}
+
+// condition Условие фильтра: ключ, оператор и одно или несколько значений
+type condition struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// Filter Отбор сообщений по ключам в стиле ecCodes. Условия перечисляются через запятую
+// и должны выполняться одновременно, например "shortName=t,typeOfLevel=isobaricInhPa,level=850/500".
+// Поддерживаются операторы =, !=, <, <=, >, >=. Для = и != несколько значений разделяются "/".
+// Числа сравниваются как числа, строки — без учета регистра. Пустой фильтр пропускает все сообщения
+type Filter []condition
+
+// ParseFilter Разбирает выражение фильтра
+func ParseFilter(expression string) (Filter, error) {
+	var filter Filter
+	for _, part := range strings.Split(expression, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		cond, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, cond)
+	}
+	return filter, nil
+}
+
+// parseCondition Разбирает условие вида "ключ<оператор>значение"
+func parseCondition(text string) (condition, error) {
+	index := strings.IndexAny(text, "!<>=")
+	if index <= 0 {
+		return condition{}, fmt.Errorf("условие %q: ожидается ключ, оператор и значение", text)
+	}
+	for _, operator := range filterOperators {
+		if !strings.HasPrefix(text[index:], operator) {
+			continue
+		}
+		cond := condition{
+			Key:      strings.TrimSpace(text[:index]),
+			Operator: operator,
+		}
+		if _, ok := messageKeys[cond.Key]; !ok {
+			return condition{}, fmt.Errorf("условие %q: неизвестный ключ %q", text, cond.Key)
+		}
+		value := strings.TrimSpace(text[index+len(operator):])
+		if value == "" {
+			return condition{}, fmt.Errorf("условие %q: нет значения", text)
+		}
+		if operator == "=" || operator == "!=" {
+			for _, alternative := range strings.Split(value, "/") {
+				cond.Values = append(cond.Values, strings.TrimSpace(alternative))
+			}
+		} else {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return condition{}, fmt.Errorf("условие %q: оператор %s требует числового значения", text, operator)
+			}
+			cond.Values = []string{value}
+		}
+		return cond, nil
+	}
+	return condition{}, fmt.Errorf("условие %q: неизвестный оператор", text)
+}
+
+// Match Сообщает, удовлетворяет ли сообщение всем условиям фильтра. Условие с ключом,
+// значение которого недоступно, не выполняется
+func (f Filter) Match(m *Message) bool {
+	for _, cond := range f {
+		value, ok := m.Get(cond.Key)
+		if !ok || !cond.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// String Возвращает выражение фильтра
+func (f Filter) String() string {
+	parts := make([]string, len(f))
+	for i, cond := range f {
+		parts[i] = cond.Key + cond.Operator + strings.Join(cond.Values, "/")
+	}
+	return strings.Join(parts, ",")
+}
+
+// match Проверяет значение ключа
+func (c condition) match(value string) bool {
+	switch c.Operator {
+	case "=":
+		return c.matchAny(value)
+	case "!=":
+		return !c.matchAny(value)
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	limit, _ := strconv.ParseFloat(c.Values[0], 64)
+	switch c.Operator {
+	case "<":
+		return number < limit
+	case "<=":
+		return number <= limit
+	case ">":
+		return number > limit
+	case ">=":
+		return number >= limit
+	}
+	return false
+}
+
+// matchAny Сообщает, совпадает ли значение с одним из значений условия
+func (c condition) matchAny(value string) bool {
+	number, numErr := strconv.ParseFloat(value, 64)
+	for _, expected := range c.Values {
+		if numErr == nil {
+			if other, err := strconv.ParseFloat(expected, 64); err == nil {
+				if number == other {
+					return true
+				}
+				continue
+			}
+		}
+		if strings.EqualFold(value, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules Правила отбора сообщений: сообщение принимается, если оно удовлетворяет хотя бы одному
+// фильтру Include (или Include пуст) и ни одному фильтру Exclude. Правила проверяются сразу после
+// чтения Секции 4, поэтому данные отброшенных сообщений не распаковываются
+type Rules struct {
+	Include []Filter
+	Exclude []Filter
+}
+
+// ParseRules Разбирает правила отбора. Фильтры в include и exclude разделяются ";",
+// например "shortName=t,level=850;shortName=10u/10v,typeOfLevel=heightAboveGround"
+func ParseRules(include string, exclude string) (Rules, error) {
+	var rules Rules
+	var err error
+	if rules.Include, err = parseFilters(include); err != nil {
+		return Rules{}, err
+	}
+	if rules.Exclude, err = parseFilters(exclude); err != nil {
+		return Rules{}, err
+	}
+	return rules, nil
+}
+
+// dataKeys Ключи секций 5-7, которые еще не прочитаны в момент проверки правил отбора
+var dataKeys = map[string]bool{
+	"dataRepresentationTemplateNumber": true,
+	"packingType":                      true,
+	"bitsPerValue":                     true,
+	"numberOfValues":                   true,
+	"bitmapPresent":                    true,
+	"numberOfMissing":                  true,
+}
+
+// parseFilters Разбирает список фильтров, разделенных ";"
+func parseFilters(expression string) ([]Filter, error) {
+	var filters []Filter
+	for _, part := range strings.Split(expression, ";") {
+		filter, err := ParseFilter(part)
+		if err != nil {
+			return nil, err
+		}
+		for _, cond := range filter {
+			if dataKeys[cond.Key] {
+				return nil, fmt.Errorf("условие %q: ключ %q недоступен до чтения Секции 5", cond.Key+cond.Operator+strings.Join(cond.Values, "/"), cond.Key)
+			}
+		}
+		if len(filter) > 0 {
+			filters = append(filters, filter)
+		}
+	}
+	return filters, nil
+}
+
+// Accept Сообщает, нужно ли загружать сообщение
+func (r Rules) Accept(m *Message) bool {
+	for _, filter := range r.Exclude {
+		if filter.Match(m) {
+			return false
+		}
+	}
+	if len(r.Include) == 0 {
+		return true
+	}
+	for _, filter := range r.Include {
+		if filter.Match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty Сообщает, что правила пропускают все сообщения
+func (r Rules) Empty() bool {
+	return len(r.Include) == 0 && len(r.Exclude) == 0
+}
@@ -0,0 +1,109 @@
+package grib2
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRulesAccept(t *testing.T) {
+	message, err := decodeBytes(t, simpleMessage(2, 1, []uint64{0, 1}, 8, 0, 0, 0, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Температура на 850 гПа, шаг 6 ч, участник ансамбля 3
+	message.Section4.Ensemble = &Ensemble{Type: 3, Number: 3, Count: 10}
+	tests := []struct {
+		name             string
+		include, exclude string
+		deterministic    bool
+		accept           bool
+	}{
+		{"без правил", "", "", false, true},
+		{"по короткому имени", "shortName=t", "", false, true},
+		{"короткое имя без учета регистра", "shortName=T", "", false, true},
+		{"другое короткое имя", "shortName=u/v", "", false, false},
+		{"один из фильтров include", "shortName=u;shortName=t,level=850", "", false, true},
+		{"уровни в диапазоне", "level>=500,level<=850", "", false, true},
+		{"уровень вне диапазона", "level>=900,level<=1000", "", false, false},
+		{"шаг в диапазоне", "step>3,step<=6", "", false, true},
+		{"шаг вне диапазона", "step<6", "", false, false},
+		{"участники ансамбля", "number>=1,number<=5", "", false, true},
+		{"другие участники", "number>5", "", false, false},
+		{"exclude важнее include", "shortName=t", "level=850", false, false},
+		{"exclude не выполнен", "shortName=t", "level=500/1000", false, true},
+		{"только exclude", "", "shortName=gh", false, true},
+		// Ключ, значение которого недоступно, не выполняет условие
+		{"номер участника у детерминированного поля", "", "number=0", true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := ParseRules(test.include, test.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			field := *message
+			if test.deterministic {
+				field.Section4.Ensemble = nil
+			}
+			if accept := rules.Accept(&field); accept != test.accept {
+				t.Fatalf("принято %v, ожидалось %v", accept, test.accept)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name             string
+		include, exclude string
+		valid            bool
+	}{
+		{"несколько фильтров", "shortName=t,level=850;shortName=10u/10v", "step>=240", true},
+		{"пустые фильтры", " ; ", "", true},
+		{"ключ секции 5 в include", "packingType=grid_simple", "", false},
+		{"ключ секции 7 в exclude", "", "numberOfMissing>0", false},
+		{"неизвестный ключ", "parameter=t", "", false},
+		{"сравнение строки", "level<high", "", false},
+		{"нет значения", "shortName=", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := ParseRules(test.include, test.exclude)
+			if (err == nil) != test.valid {
+				t.Fatalf("ошибка %v, ожидалась корректность %v", err, test.valid)
+			}
+			if test.include == " ; " && !rules.Empty() {
+				t.Fatalf("пустые фильтры сохранены: %+v", rules)
+			}
+		})
+	}
+}
+
+func TestRulesSkipData(t *testing.T) {
+	// Сложная упаковка: Секция 7 короче, чем требуют описания двух групп
+	data := testMessage{
+		ni:       2,
+		nj:       2,
+		template: 2,
+		data: bigEndian(float32(0), uint16(0), uint16(0), uint8(8), uint8(0), uint8(1), uint8(0), uint32(0), uint32(0),
+			uint32(2), uint8(0), uint8(4), uint32(0), uint8(1), uint32(2), uint8(8)),
+		points: 4,
+		values: []byte{1},
+	}.encode()
+	if _, err := decodeBytes(t, data); err == nil {
+		t.Fatal("поврежденная Секция 7 декодирована без ошибки")
+	}
+	raw, err := newMessageScanner(bytes.NewReader(data), "test").Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := ParseRules("", "shortName=t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Отброшенное сообщение не доходит до чтения секций 5-7
+	message, err := decodeRaw(raw, "test", rules)
+	if message != nil || err != nil {
+		t.Fatalf("отброшенное сообщение: %v, ошибка %v", message, err)
+	}
+}
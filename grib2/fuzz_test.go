@@ -0,0 +1,127 @@
+package grib2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// corpusFiles Возвращает файлы регрессионного корпуса testdata/corpus
+func corpusFiles(tb testing.TB) map[string][]byte {
+	tb.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.grib2"))
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("корпус не найден: %v", err)
+	}
+	files := map[string][]byte{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		files[filepath.Base(path)] = data
+	}
+	return files
+}
+
+// withTestLimits Устанавливает ограничения декодера, при которых враждебные размеры из заголовков
+// отклоняются до выделения памяти, и возвращает функцию восстановления прежних
+func withTestLimits() func() {
+	previous := DecodeLimits
+	DecodeLimits = Limits{MaxMessageSize: 1 << 20, MaxPoints: 1 << 20, MaxGroups: 1 << 16}
+	return func() { DecodeLimits = previous }
+}
+
+// decodeFile Читает все сообщения data без перехвата паники и возвращает ошибки сканера и декодера
+func decodeFile(data []byte) []error {
+	var errs []error
+	scanner := newMessageScanner(bytes.NewReader(data), "fuzz")
+	for {
+		raw, err := scanner.Next()
+		if err == io.EOF {
+			return errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			scanner.Resync()
+			continue
+		}
+		if _, err := readMsg(bytes.NewReader(raw.Body), raw.Sec0, Rules{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+}
+
+// decodeErrors Виды ошибок, которыми декодер обязан описывать любые некорректные данные
+var decodeErrors = []error{ErrTruncatedMessage, ErrUnsupportedTemplate, ErrBadEndMarker, ErrLengthMismatch, ErrCorruptData, ErrLimitExceeded}
+
+// typedError Сообщает, что ошибка относится к одному из видов decodeErrors
+func typedError(err error) bool {
+	for _, kind := range decodeErrors {
+		if errors.Is(err, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCorpusNoPanic(t *testing.T) {
+	defer withTestLimits()()
+	for name, data := range corpusFiles(t) {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("паника декодера: %v", r)
+				}
+			}()
+			for _, err := range decodeFile(data) {
+				if !typedError(err) {
+					t.Errorf("ошибка без вида: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, data := range corpusFiles(f) {
+		f.Add(data)
+	}
+	defer withTestLimits()()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, err := range decodeFile(data) {
+			if !typedError(err) {
+				t.Errorf("ошибка без вида: %v", err)
+			}
+		}
+	})
+}
+
+func TestGroupLengthsOverflow(t *testing.T) {
+	defer withTestLimits()()
+	tests := []struct {
+		name   string
+		groups []bitGroupParameter
+		want   error
+	}{
+		{"сумма длин переполняется", []bitGroupParameter{{Length: 1 << 44}, {Length: 1<<64 - 1<<44}, {Length: 10}}, ErrLimitExceeded},
+		{"длина больше количества значений", []bitGroupParameter{{Length: 11}}, ErrCorruptData},
+		{"длин меньше количества значений", []bitGroupParameter{{Length: 4}, {Length: 5}}, ErrCorruptData},
+		{"ширина больше 64 бит", []bitGroupParameter{{Width: 1 << 60, Length: 10}}, ErrCorruptData},
+		{"биты не помещаются в секцию", []bitGroupParameter{{Width: 64, Length: 10}}, ErrLengthMismatch},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := checkLengths(test.groups, 16, 10); !errors.Is(err, test.want) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.want)
+			}
+		})
+	}
+	total, err := checkLengths([]bitGroupParameter{{Width: 8, Length: 4}, {Length: 6}}, 16, 10)
+	if err != nil || total != 10 {
+		t.Fatalf("значений %d, ошибка %v", total, err)
+	}
+}
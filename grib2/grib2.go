@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"gribV2.com/config"
 	"io"
-	"log"
 
 
 	"time"
@@ -27,211 +26,178 @@ const (
 	SupportedGribEdition = 2
 )
 
-// readMessages Основная функция, которая запускает чтение мета-данных из начала файла, затем начинает читать секции и сообщения, после отправляет полученные данные на запись в опреедленном формате
-func readMessages(file io.Reader, bufChannel chan<- *Table, msg chan<- *Message) error {
+// readMessages Основная функция, которая разбивает файл на сообщения, декодирует их параллельно и отправляет полученные данные на запись в опреедленном формате
+func readMessages(file io.Reader, name string, bufChannel chan<- *Table, msg chan<- *Message) error {
 	defer config.Logger.Info("Чтение файла завершено")
-	for {
-		// Чтение мета-данных из начала файла
-		err := readMeta(file)
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-			return err
-		}
-		// Начинает читать сообщения из файла согласно разметке GRIB2 <GRIB ----- 7777>
-		message, err := readMessage(file)
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil /// Конец файла
-			}
-			config.Logger.WithError(err).Error("Ошибка чтения сообщения")
-			return err
-		}
-		// Создание переменных, которые будут в последствии записаны
-		// //-----------------------------------------------------------------------------------------------------
-		// UUID
-		id := uuid.New()
-		// timestamp
-		date := time.Date(int(message.Section1.ReferenceTime.Year), time.Month(message.Section1.ReferenceTime.Month), int(message.Section1.ReferenceTime.Day), int(message.Section1.ReferenceTime.Hour), int(message.Section1.ReferenceTime.Minute), int(message.Section1.ReferenceTime.Second), 0, time.UTC)
-		// forecasttime (время прогноза)
-		forcasttime := message.Section4.ProductDefinitionTemplate.ForecastTime
-		// parameter (температура, давление, влажность...)
-		param := ReadProductDisciplineCategoryParameters(uint16(message.Section0.Discipline), message.Section4.ProductDefinitionTemplate.ParameterCategory, message.Section4.ProductDefinitionTemplate.ParameterNumber)
-		// surface_type Тип поверхности
-		surfaceType := ReadSurfaceTypesUnits(int(message.Section4.ProductDefinitionTemplate.FirstSurface.Type))
-		// surface_value Высота
-		surfaceValue := fmt.Sprintf("%dm", message.Section4.ProductDefinitionTemplate.FirstSurface.Value)
-		//Параметры сетки сохраняются в формате json
-		var s3 S3
-		// Название сетки
-		s3.Name = Name
-		// Ее параметры
-		s3.Sec3 = message.Section3
-		//
-		// grib_data Массив точек float64
-		data := message.Section7.Data
-		// grib_data_int Массив точек int
-		data_int := make([]int, len(message.Section7.Data))
-		for i, v := range message.Section7.Data {
-			data_int[i] = int(v)
-		}
-		// Структура, записываемая в базу данных
-		table := &Table{
-			UUID:         id,
-			Date:         date,
-			ForecastTime: forcasttime,
-			Param:        param,
-			SurfaceType:  surfaceType,
-			SurfaceValue: surfaceValue,
-			Section3:     s3,
-			Data:         data,
-			Data_int:     data_int,
-		}
+	err := decodeMessages(file, name, func(message *Message) error {
 		// Если требуется сохранение в json по секциям, как в сообщении, то отправляется message, а не table
 		if SaveAs == "jsonSec" {
 			msg <- message
 		} else {
+			table := newTable(message)
+			table.source = name
+			for _, table := range Stages.Process(table) {
+				bufChannel <- table
+			}
+		}
+		return nil
+	})
+	if SaveAs != "jsonSec" {
+		// Поля, которые этапы обработки накапливали до конца файла
+		for _, table := range Stages.FlushSource(name) {
 			bufChannel <- table
 		}
 	}
-
-}
-// Параметры сетки
-type S3 struct {
-	Name string `json:"name"`
-	Sec3 Section3
+	return err
 }
 
-// readMeta Читает мета-данные из начала файла и отсеивает их
-func readMeta(file io.Reader) error {
-	var b byte
-	for {
-		err := binary.Read(file, binary.BigEndian, &b)
-		if err != nil {
-			return err
-		}
-		if b == 'G' {
-			err := binary.Read(file, binary.BigEndian, &b)
-			if err != nil {
-				return err
-			}
-			if b == 'R' {
-				err := binary.Read(file, binary.BigEndian, &b)
-				if err != nil {
-					return err
-				}
-				if b == 'I' {
-					err := binary.Read(file, binary.BigEndian, &b)
-					if err != nil {
-						return err
-					}
-					if b == 'B' {
-						return nil
-					}
-				}
-			}
-		}
+// newTable Создает из сообщения структуру, записываемую в базу данных
+func newTable(message *Message) *Table {
+	// UUID
+	id := uuid.New()
+	// timestamp
+	date := time.Date(int(message.Section1.ReferenceTime.Year), time.Month(message.Section1.ReferenceTime.Month), int(message.Section1.ReferenceTime.Day), int(message.Section1.ReferenceTime.Hour), int(message.Section1.ReferenceTime.Minute), int(message.Section1.ReferenceTime.Second), 0, time.UTC)
+	// forecasttime (время прогноза)
+	forcasttime := message.Section4.ProductDefinitionTemplate.ForecastTime
+	// parameter (температура, давление, влажность...)
+	param := LookupParameter(message.Section1, message.Section0.Discipline, message.Section4.ProductDefinitionTemplate.ParameterCategory, message.Section4.ProductDefinitionTemplate.ParameterNumber)
+	// surface_type Тип поверхности
+	surfaceType := ReadSurfaceTypesUnits(int(message.Section4.ProductDefinitionTemplate.FirstSurface.Type))
+	// surface_value Высота
+	surfaceValue := ""
+	if _, ok := message.Section4.ProductDefinitionTemplate.FirstSurface.Float(); ok {
+		surfaceValue = fmt.Sprintf("%dm", message.Section4.ProductDefinitionTemplate.FirstSurface.Value)
 	}
-}
-
-// readMessage Читает одно сообщение и отдает его на запись
-func readMessage(file io.Reader) (*Message, error) {
-	//Создает новую структуру Message
-	message := Message{}
-	// Читает Секцию 0
-	sec0, headErr := readSec0(file)
-	if headErr != nil {
-		return &message, headErr
+	//Параметры сетки сохраняются в формате json
+	var s3 S3
+	// Название сетки
+	s3.Name = GridName(message.Section3.TemplateNumber)
+	// Ее параметры
+	s3.Sec3 = message.Section3
+	// grib_data Массив точек float64
+	data := message.Section7.Data
+	// grib_data_int Упакованные коды значений и параметры упаковки для их восстановления
+	data_int := message.Section7.Codes
+	if data_int == nil {
+		data_int = []int32{}
 	}
-
-	// Создание массива байт размером полученным из Секции 0
-	msgBytes := make([]byte, sec0.MessageLength-16)
-	// Читает все оставшиеся байты в этот массив
-	numBytes, readErr := file.Read(msgBytes)
-
-	if readErr != nil {
-		config.Logger.Warn("Ошибка чтения сообщения!")
-		return &message, readErr
+	packing, _ := message.Section5.Packing()
+	// Интервал статистической обработки, для мгновенных полей начало и конец совпадают
+	windowStart, windowEnd, _ := message.Window()
+	process := message.Section4.Interval.Process()
+	// Участник ансамбля из шаблонов 4.1 и 4.11
+	ensemble := Ensemble{Type: EnsembleNone}
+	if message.Section4.Ensemble != nil {
+		ensemble = *message.Section4.Ensemble
 	}
-	if numBytes != int(sec0.MessageLength-16) {
-		config.Logger.Warn("Все сообщение не прочитано!")
-		errString := fmt.Sprintf("Ожидаемый размер(%v) и прочитанный(%v) не совпадают!", int(sec0.MessageLength-16), numBytes)
-		return &message, errors.New(errString)
+	return &Table{
+		UUID:               id,
+		Date:               date,
+		ForecastTime:       forcasttime,
+		Parameter:          param,
+		SurfaceType:        surfaceType,
+		SurfaceValue:       surfaceValue,
+		Section3:           s3,
+		Data:               data,
+		Data_int:           data_int,
+		MissingCount:       message.Section7.Missing,
+		Reference:          packing.Reference,
+		BinaryScale:        packing.BinaryScale,
+		DecimalScale:       packing.DecimalScale,
+		Legend:             param.Legend(message.Section1, data),
+		WindowStart:        windowStart,
+		WindowEnd:          windowEnd,
+		StatisticalProcess: process,
+		StepType:           StepType(process),
+		EnsembleType:       ensemble.Type,
+		EnsembleMember:     ensemble.Number,
+		EnsembleSize:       ensemble.Count,
+		Statistics:         ComputeStatistics(data, Percentiles),
+		surface:            message.Section4.ProductDefinitionTemplate.FirstSurface,
+		timeUnit:           message.Section4.ProductDefinitionTemplate.TimeUnitIndicator,
+		section1:           message.Section1,
 	}
-	// Возвращает результат работы функции readMsg, которая парсит следущие секции
-	return readMsg(bytes.NewReader(msgBytes), sec0)
 }
 
-// readSec0 Парсит Секцию 0 согласно ее структуре
-func readSec0(file io.Reader) (sec0 Section0, err error) {
-
-	err = binary.Read(file, binary.BigEndian, &sec0)
-	if err != nil {
-		return sec0, err
-	}
-	if Grib == Grib {
-		if sec0.Edition != SupportedGribEdition {
-			return sec0, errors.New("Ошибка формата гриба")
-		}
-	} else {
-		return sec0, errors.New("Ошибка индикатора")
-	}
-	return
+// Параметры сетки
+type S3 struct {
+	Name string `json:"name"`
+	Sec3 Section3
 }
 
-// readMsg читает оставшиеся секции из сообщения
-func readMsg(msg io.Reader, sec0 Section0) (*Message, error) {
+// readMsg читает оставшиеся секции из сообщения. Если сообщение не проходит правила отбора rules,
+// чтение прекращается после Секции 4 и возвращается nil без ошибки
+func readMsg(msg io.Reader, sec0 Section0, rules Rules) (*Message, error) {
 	message := Message{
 		Section0: sec0,
 	}
+	// Смещение текущей секции от начала тела сообщения
+	var offset int64
+	remaining := int64(sec0.MessageLength) - 16
 	for {
 		// Читает заголовок секции, чтобы понять какую секцию читать
 		sectionHead, headErr := readSectionHead(msg)
 		if headErr != nil {
-			log.Println("Error reading header", headErr.Error())
-			return &message, headErr
+			if errors.Is(headErr, io.EOF) || errors.Is(headErr, io.ErrUnexpectedEOF) {
+				headErr = fmt.Errorf("%w: нет маркера \"7777\"", ErrBadEndMarker)
+			}
+			return &message, sectionError(8, offset, headErr)
 		}
-		// Проверка длинные заголовка
-		if sectionHead.ContentLength() > 0 {
-			var rawData = make([]byte, sectionHead.ContentLength())
-			err := binary.Read(msg, binary.BigEndian, &rawData)
-			if err != nil {
-				return &message, err
+		if sectionHead.Number == 8 {
+			// end-section, return
+			if remaining != 4 {
+				return &message, sectionError(8, offset, fmt.Errorf("%w: маркер \"7777\" найден за %d байт до конца сообщения", ErrLengthMismatch, remaining-4))
 			}
-			byteReader := bytes.NewBuffer(rawData)
-			// Выбор секции
-			switch sectionHead.Number {
-
-			case 1:
-				message.Section1, err = ReadSection1(byteReader, sectionHead.ContentLength())
-			case 2:
-				message.Section2, err = ReadSection2(byteReader, sectionHead.ContentLength())
-			case 3:
-				message.Section3, err = ReadSection3(byteReader, sectionHead.ContentLength())
-			case 4:
-				message.Section4, err = ReadSection4(byteReader, sectionHead.ContentLength())
-			case 5:
-				message.Section5, err = ReadSection5(byteReader, sectionHead.ContentLength())
-			case 6:
-				message.Section6, err = ReadSection6(byteReader, sectionHead.ContentLength())
-			case 7:
-				message.Section7, err = ReadSection7(byteReader, sectionHead.ContentLength(), message.Section5)
-			case 8:
-				// end-section, return
-				return &message, nil
-
-			default:
-				err = fmt.Errorf("Unknown section number %d  (Something bad with parser or files)", sectionHead.Number)
+			return &message, nil
+		}
+		// Проверка длины секции
+		if sectionHead.ContentLength() < 0 || int64(sectionHead.ByteLength) > remaining-4 {
+			return &message, sectionError(sectionHead.Number, offset, fmt.Errorf("%w: длина секции %d, до конца сообщения %d", ErrLengthMismatch, sectionHead.ByteLength, remaining))
+		}
+		var rawData = make([]byte, sectionHead.ContentLength())
+		err := binary.Read(msg, binary.BigEndian, &rawData)
+		if err != nil {
+			return &message, sectionError(sectionHead.Number, offset, fmt.Errorf("%w: %s", ErrTruncatedMessage, err.Error()))
+		}
+		byteReader := bytes.NewBuffer(rawData)
+		// Выбор секции
+		switch sectionHead.Number {
+
+		case 1:
+			message.Section1, err = ReadSection1(byteReader, sectionHead.ContentLength())
+		case 2:
+			message.Section2, err = ReadSection2(byteReader, sectionHead.ContentLength())
+		case 3:
+			message.Section3, err = ReadSection3(byteReader, sectionHead.ContentLength())
+		case 4:
+			message.Section4, err = ReadSection4(byteReader, sectionHead.ContentLength())
+			if err == nil && !rules.Empty() && !rules.Accept(&message) {
+				return nil, nil
 			}
-			if err != nil {
-				return &message, err
+		case 5:
+			message.Section5, err = ReadSection5(byteReader, sectionHead.ContentLength())
+		case 6:
+			message.Section6, err = ReadSection6(byteReader, sectionHead.ContentLength())
+		case 7:
+			message.Section7, err = ReadSection7(byteReader, sectionHead.ContentLength(), message.Section5)
+			if err == nil {
+				err = message.Section7.applyBitmap(message.Section6, message.Section3.DataPointCount)
 			}
-		} else {
-			return &message, nil
+
+		default:
+			err = fmt.Errorf("%w: неизвестный номер секции %d", ErrCorruptData, sectionHead.Number)
 		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				err = fmt.Errorf("%w: %s", ErrTruncatedMessage, err.Error())
+			}
+			return &message, sectionError(sectionHead.Number, offset, err)
+		}
+		offset += int64(sectionHead.ByteLength)
+		remaining -= int64(sectionHead.ByteLength)
 	}
 }
+
 // readSectionHead Читает заголовок секции, определяет его номер и размер
 func readSectionHead(msg io.Reader) (head SectionHead, err error) {
 	var length uint32
@@ -401,6 +367,9 @@ func ReadSection3(f io.Reader, _ int) (section Section3, err error) {
 	if err != nil {
 		return section, err
 	}
+	if err := checkLimit("количество точек сетки", uint64(section.DataPointCount), DecodeLimits.MaxPoints); err != nil {
+		return section, err
+	}
 	// Определяет сетку и записывает ее в структуру
 	section.Definition, err = ReadGrid(f, section.TemplateNumber)
 	return section, err
@@ -416,10 +385,12 @@ func ReadSection3(f io.Reader, _ int) (section Section3, err error) {
 // |              | the number given in octets 8-9)
 // | [xx+1]-nn    | Optional list of coordinate values (See notes 2 and 3 below)
 type Section4 struct {
-	CoordinatesCount                uint16   `json:"coordinatesCount"`
-	ProductDefinitionTemplateNumber uint16   `json:"productDefinitionTemplateNumber"`
-	ProductDefinitionTemplate       Product0 `json:"productDefinitionTemplate"` // FIXME
-	Coordinates                     []byte   `json:"coordinates"`
+	CoordinatesCount                uint16    `json:"coordinatesCount"`
+	ProductDefinitionTemplateNumber uint16    `json:"productDefinitionTemplateNumber"`
+	ProductDefinitionTemplate       Product0  `json:"productDefinitionTemplate"` // FIXME
+	Ensemble                        *Ensemble `json:"ensemble,omitempty"`        // Участник ансамбля для шаблонов 4.1 и 4.11
+	Interval                        *Interval `json:"interval,omitempty"`        // Интервал статистической обработки для шаблонов 4.8 и 4.11
+	Coordinates                     []byte    `json:"coordinates"`
 }
 // ReadSection4 Читает определенный в заголовке размер байт в структуру Section4
 func ReadSection4(f io.Reader, length int) (section Section4, err error) {
@@ -430,6 +401,24 @@ func ReadSection4(f io.Reader, length int) (section Section4, err error) {
 	switch section.ProductDefinitionTemplateNumber {
 	case 0:
 		err = read(f, &section.ProductDefinitionTemplate)
+		section.ProductDefinitionTemplate.fixSigned()
+	case 1:
+		section.Ensemble = &Ensemble{}
+		err = read(f, &section.ProductDefinitionTemplate, section.Ensemble)
+		section.ProductDefinitionTemplate.fixSigned()
+	case 8:
+		err = read(f, &section.ProductDefinitionTemplate)
+		section.ProductDefinitionTemplate.fixSigned()
+		if err == nil {
+			section.Interval, err = readInterval(f)
+		}
+	case 11:
+		section.Ensemble = &Ensemble{}
+		err = read(f, &section.ProductDefinitionTemplate, section.Ensemble)
+		section.ProductDefinitionTemplate.fixSigned()
+		if err == nil {
+			section.Interval, err = readInterval(f)
+		}
 	default:
 		return section, nil
 	}
@@ -456,16 +445,40 @@ type Section5 struct {
 }
 // ReadSection5 Читает определенный в заголовке размер байт в структуру Section5
 func ReadSection5(f io.Reader, length int) (section Section5, err error) {
+	if length < 6 {
+		return section, fmt.Errorf("%w: длина Секции 5 %d", ErrLengthMismatch, length+5)
+	}
 	section.Data = make([]byte, length-6)
 	err = read(f, &section.PointsNumber, &section.DataTemplateNumber, &section.Data)
 	if err != nil {
 		return section, err
 	}
+	if err := checkLimit("количество значений", uint64(section.PointsNumber), DecodeLimits.MaxPoints); err != nil {
+		return section, err
+	}
 	if section.DataTemplateNumber != 0 && section.DataTemplateNumber != 2 && section.DataTemplateNumber != 3 {
-		return section, fmt.Errorf("Template number not supported: %d", section.DataTemplateNumber)
+		return section, fmt.Errorf("%w: шаблон представления данных 5.%d", ErrUnsupportedTemplate, section.DataTemplateNumber)
 	}
 	return section, nil
 }
+// Packing Возвращает параметры упаковки (опорное значение, масштабные множители, ширину),
+// общие для шаблонов 5.0, 5.2 и 5.3
+func (section Section5) Packing() (Data0, bool) {
+	data, err := section.GetDataTemplate()
+	if err != nil {
+		return Data0{}, false
+	}
+	switch x := data.(type) {
+	case Data0:
+		return x, true
+	case Data2:
+		return x.Data0, true
+	case Data3:
+		return x.Data0, true
+	}
+	return Data0{}, false
+}
+
 // GetDataTemplate Получает шаблон хранения данных согласно структуре секций
 func (section Section5) GetDataTemplate() (interface{}, error) {
 	switch section.DataTemplateNumber {
@@ -473,17 +486,20 @@ func (section Section5) GetDataTemplate() (interface{}, error) {
 	case 0:
 		data := Data0{}
 		read(bytes.NewReader(section.Data), &data)
+		data.fixSigned()
 		return data, nil
 	case 2:
 		data := Data2{}
 		read(bytes.NewReader(section.Data), &data)
+		data.fixSigned()
 		return data, nil
 	case 3:
 		data := Data3{}
 		read(bytes.NewReader(section.Data), &data)
+		data.fixSigned()
 		return data, nil
 	}
-	return struct{}{}, fmt.Errorf("Unknown data format")
+	return struct{}{}, fmt.Errorf("%w: шаблон представления данных 5.%d", ErrUnsupportedTemplate, section.DataTemplateNumber)
 }
 
 //	| Octet Number | Content
@@ -500,6 +516,9 @@ type Section6 struct {
 }
 // ReadSection6 Читает определенный в заголовке размер байт в структуру Section6
 func ReadSection6(f io.Reader, length int) (section Section6, err error) {
+	if length < 1 {
+		return section, fmt.Errorf("%w: пустая Секция 6", ErrLengthMismatch)
+	}
 	section.Bitmap = make([]byte, length-1)
 	return section, read(f, &section.BitmapIndicator, &section.Bitmap)
 }
@@ -511,32 +530,62 @@ func ReadSection6(f io.Reader, length int) (section Section6, err error) {
 // | 6-nn         | Data in a format described by data Template 7.X, where X is the data representation template number
 // |              | given in octets 10-11 of Section 5.
 type Section7 struct {
-	Data []float64 `json:"data"`
+	Data    Values  `json:"data"`
+	Missing int     `json:"missing"` // Количество точек без данных
+	Codes   []int32 `json:"-"`       // Упакованные целые коды значений, заполняются при StorePackedCodes
 }
 // ReadSection7 Читает определенный в заголовке размер байт в структуру Section7
 func ReadSection7(f io.Reader, length int, section5 Section5) (section Section7, sectionError error) {
-	defer func() {
-		if err := recover(); err != nil {
-			log.Printf("Corrupt message %q\n", err)
-		}
-	}()
 	data, sectionError := section5.GetDataTemplate()
 	if sectionError != nil {
 		return Section7{}, sectionError
 	}
+	// Групп при сложной упаковке не может быть больше, чем значений
+	switch x := data.(type) {
+	case Data2:
+		if x.NG > section5.PointsNumber {
+			return section, fmt.Errorf("%w: групп %d больше, чем значений %d", ErrCorruptData, x.NG, section5.PointsNumber)
+		}
+	case Data3:
+		if x.NG > section5.PointsNumber {
+			return section, fmt.Errorf("%w: групп %d больше, чем значений %d", ErrCorruptData, x.NG, section5.PointsNumber)
+		}
+	}
+	// Постоянное поле декодируется без Секции 7, при сложной упаковке только если в ней нет пропусков и групп
+	packing, _ := section5.Packing()
+	if constantField(data, length) {
+		section.Data, sectionError = ParseConstant(&packing, section5.PointsNumber)
+		if sectionError == nil && StorePackedCodes {
+			section.Codes = make([]int32, len(section.Data))
+		}
+		return section, sectionError
+	}
 	if length != 0 {
+		var codes, ifldmiss []int64
 		switch x := data.(type) {
 		case Data0:
-			section.Data, sectionError = ParseData0(f, length, &x)
+			codes, sectionError = unpackData0(f, length, &x)
+			// Последний байт секции может содержать биты выравнивания, которые не являются значениями
+			if len(codes) > int(section5.PointsNumber) {
+				codes = codes[:section5.PointsNumber]
+			}
+			section.Data = x.scaleCodes(codes)
 		case Data2:
-			section.Data, sectionError = ParseData2(f, length, &x)
+			codes, ifldmiss, sectionError = unpackData2(f, length, section5.PointsNumber, &x)
+			section.Data, section.Missing = x.scaleValues(codes, ifldmiss)
 		case Data3:
-			section.Data, sectionError = ParseData3(f, length, &x)
+			codes, ifldmiss, sectionError = unpackData3(f, length, section5.PointsNumber, &x)
+			section.Data, section.Missing = x.scaleValues(codes, ifldmiss)
 		default:
-			sectionError = fmt.Errorf("Unknown data type")
+			sectionError = fmt.Errorf("%w: шаблон представления данных 5.%d", ErrUnsupportedTemplate, section5.DataTemplateNumber)
 			return
 		}
-
+		if sectionError != nil {
+			return Section7{}, sectionError
+		}
+		if StorePackedCodes {
+			section.Codes = packedCodes(codes, ifldmiss)
+		}
 	}
 	return section, sectionError
 }
@@ -0,0 +1,256 @@
+package grib2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"gribV2.com/config"
+)
+
+// TestMain Создает логер без вывода: этапы и декодер записывают предупреждения в config.Logger
+func TestMain(m *testing.M) {
+	config.Logger = logrus.New()
+	config.Logger.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// section Собирает секцию num с содержимым content
+func section(num uint8, content []byte) []byte {
+	buffer := new(bytes.Buffer)
+	binary.Write(buffer, binary.BigEndian, uint32(len(content)+5))
+	buffer.WriteByte(num)
+	buffer.Write(content)
+	return buffer.Bytes()
+}
+
+// bigEndian Записывает значения подряд в порядке big-endian
+func bigEndian(values ...interface{}) []byte {
+	buffer := new(bytes.Buffer)
+	for _, value := range values {
+		binary.Write(buffer, binary.BigEndian, value)
+	}
+	return buffer.Bytes()
+}
+
+// signMagnitude Записывает целое со знаком в прямом коде, как в GRIB2
+func signMagnitude(value int32) uint32 {
+	if value < 0 {
+		return uint32(-value) | 0x80000000
+	}
+	return uint32(value)
+}
+
+// bitWriter Записывает целые произвольной разрядности старшим битом вперед
+type bitWriter struct {
+	out []byte
+	acc uint64
+	n   uint
+}
+
+// put Записывает младшие bits бит значения value
+func (w *bitWriter) put(value uint64, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		w.acc = w.acc<<1 | (value>>uint(i))&1
+		w.n++
+		if w.n == 8 {
+			w.out = append(w.out, byte(w.acc))
+			w.acc, w.n = 0, 0
+		}
+	}
+}
+
+// align Дополняет запись нулями до границы байта
+func (w *bitWriter) align() {
+	for w.n != 0 {
+		w.put(0, 1)
+	}
+}
+
+// testMessage Синтетическое сообщение: температура на 850 гПа (продукт 4.0) на широтно-долготной
+// сетке ni x nj с севера на юг от 60° с. ш., 0° в. д. и шагом 1°
+type testMessage struct {
+	ni, nj   uint32
+	forecast uint32
+	minutes  bool   // Время прогноза в минутах, по умолчанию в часах
+	interval []byte // Интервал статистической обработки шаблона 4.8, по умолчанию шаблон 4.0
+	template uint16 // Шаблон представления данных
+	data     []byte // Секция 5 после номера шаблона
+	points   uint32 // Количество значений из Секции 5
+	bitmap   []byte // Секция 6, по умолчанию битовой карты нет
+	values   []byte // Секция 7
+}
+
+// encode Возвращает сообщение целиком, от "GRIB" до "7777"
+func (m testMessage) encode() []byte {
+	bitmap := m.bitmap
+	if bitmap == nil {
+		bitmap = []byte{255}
+	}
+	s1 := bigEndian(uint16(7), uint16(0), uint8(2), uint8(1), uint8(1), uint16(2024), uint8(1), uint8(2), uint8(6), uint8(0), uint8(0), uint8(0), uint8(1))
+	grid := bigEndian(uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), m.ni, m.nj, uint32(0), uint32(0xffffffff),
+		signMagnitude(60000000), signMagnitude(0), uint8(48), signMagnitude(60000000-1000000*int32(m.nj-1)), signMagnitude(1000000*int32(m.ni-1)),
+		signMagnitude(1000000), signMagnitude(1000000), uint8(0))
+	s3 := append(bigEndian(uint8(0), m.ni*m.nj, uint8(0), uint8(0), uint16(0)), grid...)
+	product, unit := uint16(0), uint8(1)
+	if m.interval != nil {
+		product = 8
+	}
+	if m.minutes {
+		unit = 0
+	}
+	s4 := bigEndian(uint16(0), product, uint8(0), uint8(0), uint8(2), uint8(0), uint8(96), uint16(0), uint8(0), unit, m.forecast,
+		uint8(100), uint8(0), uint32(85000), uint8(255), uint8(0), uint32(0))
+	s4 = append(s4, m.interval...)
+	s5 := append(bigEndian(m.points, m.template), m.data...)
+	body := bytes.Join([][]byte{section(1, s1), section(3, s3), section(4, s4), section(5, s5), section(6, bitmap), section(7, m.values), []byte("7777")}, nil)
+	head := append([]byte("GRIB"), bigEndian(uint16(0), uint8(0), uint8(2), uint64(len(body)+16))...)
+	return append(head, body...)
+}
+
+// simpleMessage Возвращает сообщение с простой упаковкой (5.0) кодов codes разрядности bits,
+// опорным значением reference и масштабными множителями e и d в записи знак-модуль
+func simpleMessage(ni, nj uint32, codes []uint64, bits uint8, reference float32, e, d uint16, forecast uint32) []byte {
+	w := &bitWriter{}
+	for _, code := range codes {
+		w.put(code, int(bits))
+	}
+	w.align()
+	return testMessage{
+		ni:       ni,
+		nj:       nj,
+		forecast: forecast,
+		template: 0,
+		data:     bigEndian(reference, e, d, bits, uint8(0)),
+		points:   uint32(len(codes)),
+		values:   w.out,
+	}.encode()
+}
+
+// testField Поле из сообщения simpleMessage на сетке ni x nj с 8-битными кодами codes, по умолчанию
+// нулевыми. Параметр, поверхность и сетка сообщения заменяются до создания поля
+type testField struct {
+	ni, nj           uint32
+	codes            []uint64
+	category, number uint8       // Параметр дисциплины 0, по умолчанию температура
+	surface          *Surface    // Первая поверхность, по умолчанию 850 гПа
+	definition       interface{} // Определение сетки вместо широтно-долготной от 60° с. ш., 0° в. д.
+	data             Values      // Значения вместо декодированных
+}
+
+// table Декодирует сообщение и возвращает поле
+func (f testField) table(t *testing.T) *Table {
+	t.Helper()
+	codes := f.codes
+	if codes == nil {
+		codes = make([]uint64, f.ni*f.nj)
+	}
+	message, err := decodeBytes(t, simpleMessage(f.ni, f.nj, codes, 8, 0, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := &message.Section4.ProductDefinitionTemplate
+	product.ParameterCategory, product.ParameterNumber = f.category, f.number
+	if f.surface != nil {
+		product.FirstSurface = *f.surface
+	}
+	if f.definition != nil {
+		message.Section3.Definition = f.definition
+	}
+	field := newTable(message)
+	if f.data != nil {
+		field.Data = f.data
+	}
+	return field
+}
+
+// decodeBytes Декодирует единственное сообщение data
+func decodeBytes(t *testing.T, data []byte) (*Message, error) {
+	t.Helper()
+	raw, err := newMessageScanner(bytes.NewReader(data), "test").Next()
+	if err != nil {
+		return nil, err
+	}
+	return decodeRaw(raw, "test", Rules{})
+}
+
+// testGroup Группа сложной упаковки. Для группы нулевой ширины значения не записываются,
+// а их количество задает длину группы
+type testGroup struct {
+	reference uint64
+	width     uint8
+	values    []uint64
+}
+
+// complexPacking Поле со сложной упаковкой (5.2, при spatial — 5.3 с дифференцированием первого
+// порядка) с опорным значением 100 и масштабными множителями 0
+type complexPacking struct {
+	spatial     bool
+	ival1       uint16 // Первое значение для восстановления дифференцирования
+	minsd       uint16 // Минимум разностей
+	bits        uint8  // Разрядность опорных значений групп
+	management  uint8  // Управление пропусками (Code Table 5.5)
+	kind        uint8  // Тип исходных значений (Code Table 5.1)
+	substitutes [2]uint32
+	groups      []testGroup
+}
+
+// encode Возвращает сообщение с полем на сетке из одной строки
+func (p complexPacking) encode() []byte {
+	var points uint32
+	for _, group := range p.groups {
+		points += uint32(len(group.values))
+	}
+	last := uint32(0)
+	if len(p.groups) > 0 {
+		last = uint32(len(p.groups[len(p.groups)-1].values))
+	}
+	template := uint16(2)
+	data := bigEndian(float32(100), uint16(0), uint16(0), p.bits, p.kind, uint8(1), p.management, p.substitutes[0], p.substitutes[1],
+		uint32(len(p.groups)), uint8(0), uint8(4), uint32(0), uint8(1), last, uint8(8))
+	w := &bitWriter{}
+	if p.spatial {
+		template = 3
+		data = append(data, 1, 2)
+		w.put(uint64(p.ival1), 16)
+		w.put(uint64(p.minsd), 16)
+	}
+	for _, group := range p.groups {
+		w.put(group.reference, int(p.bits))
+	}
+	w.align()
+	for _, group := range p.groups {
+		w.put(uint64(group.width), 4)
+	}
+	w.align()
+	for _, group := range p.groups {
+		w.put(uint64(len(group.values)), 8)
+	}
+	w.align()
+	for _, group := range p.groups {
+		if group.width == 0 {
+			continue
+		}
+		for _, value := range group.values {
+			w.put(value, int(group.width))
+		}
+	}
+	w.align()
+	return testMessage{ni: points, nj: 1, template: template, data: data, points: points, values: w.out}.encode()
+}
+
+// sameValues Сравнивает значения, считая пропуски равными друг другу
+func sameValues(got, want Values) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if IsMissing(got[i]) != IsMissing(want[i]) || !IsMissing(got[i]) && got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
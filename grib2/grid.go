@@ -2,18 +2,30 @@ package grib2
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
 )
 
-func fixNegLatLon(num int32) int32 {
-	if num < 0 {
-		return -int32(uint32(num) &^ uint32(0x80000000))
+// GridName Возвращает название сетки по номеру шаблона Секции 3
+func GridName(templateNumber uint16) string {
+	switch templateNumber {
+	case 0:
+		return "Latitude/longitude (or equidistant cylindrical, or Plate Carree)"
+	case 10:
+		return "Mercator"
+	case 20:
+		return "Polar stereographic projection"
+	case 30:
+		return "Lambert conformal Polar stereographic projection "
+	case 40:
+		return "Gaussian latitude/longitude "
+	case 90:
+		return "Space view perspective or orthographic"
+	default:
+		return ""
 	}
-	return num
 }
-var Name string
+
 func ReadGrid(f io.Reader, templateNumber uint16) (Grid, error) {
 	var err error
 	var g Grid
@@ -21,57 +33,68 @@ func ReadGrid(f io.Reader, templateNumber uint16) (Grid, error) {
 	case 0:
 		var grid Grid0
 		err = binary.Read(f, binary.BigEndian, &grid)
-		grid.La1 = fixNegLatLon(grid.La1)
-		grid.Lo1 = fixNegLatLon(grid.Lo1)
-		grid.La2 = fixNegLatLon(grid.La2)
-		grid.Lo2 = fixNegLatLon(grid.Lo2)
-		Name = "Latitude/longitude (or equidistant cylindrical, or Plate Carree)"
+		grid.La1 = signed32(grid.La1)
+		grid.Lo1 = signed32(grid.Lo1)
+		grid.La2 = signed32(grid.La2)
+		grid.Lo2 = signed32(grid.Lo2)
+		grid.fixSigned()
 		g = &grid
 
 	case 10:
 		var grid Grid10
 		err = binary.Read(f, binary.BigEndian, &grid)
-		grid.La1 = fixNegLatLon(grid.La1)
-		grid.Lo1 = fixNegLatLon(grid.Lo1)
-		grid.La2 = fixNegLatLon(grid.La2)
-		grid.Lo2 = fixNegLatLon(grid.Lo2)
-		Name="Mercator"
+		grid.La1 = signed32(grid.La1)
+		grid.Lo1 = signed32(grid.Lo1)
+		grid.La2 = signed32(grid.La2)
+		grid.Lo2 = signed32(grid.Lo2)
+		grid.Lad = signed32(grid.Lad)
+		grid.fixSigned()
 		g = &grid
 
 	case 20:
 		var grid Grid20
 		err = binary.Read(f, binary.BigEndian, &grid)
-		grid.La1 = fixNegLatLon(grid.La1)
-		grid.Lo1 = fixNegLatLon(grid.Lo1)
-		Name="Polar stereographic projection"
+		grid.La1 = signed32(grid.La1)
+		grid.Lo1 = signed32(grid.Lo1)
+		grid.Lad = signed32(grid.Lad)
+		grid.Lov = signed32(grid.Lov)
+		grid.fixSigned()
 		g = &grid
 
 	case 30:
 		var grid Grid30
 		err = binary.Read(f, binary.BigEndian, &grid)
-		grid.La1 = fixNegLatLon(grid.La1)
-		grid.Lo1 = fixNegLatLon(grid.Lo1)
-		Name="Lambert conformal Polar stereographic projection "
+		grid.La1 = signed32(grid.La1)
+		grid.Lo1 = signed32(grid.Lo1)
+		grid.Lad = signed32(grid.Lad)
+		grid.Lov = signed32(grid.Lov)
+		grid.Latin1 = signed32(grid.Latin1)
+		grid.Latin2 = signed32(grid.Latin2)
+		grid.LaSouthPole = signed32(grid.LaSouthPole)
+		grid.LoSouthPole = signed32(grid.LoSouthPole)
+		grid.fixSigned()
 		g = &grid
 
 	case 40:
 		var grid Grid40
 		err = binary.Read(f, binary.BigEndian, &grid)
-		grid.La1 = fixNegLatLon(grid.La1)
-		grid.Lo1 = fixNegLatLon(grid.Lo1)
-		grid.La2 = fixNegLatLon(grid.La2)
-		grid.Lo2 = fixNegLatLon(grid.Lo2)
-		Name="Gaussian latitude/longitude "
+		grid.La1 = signed32(grid.La1)
+		grid.Lo1 = signed32(grid.Lo1)
+		grid.La2 = signed32(grid.La2)
+		grid.Lo2 = signed32(grid.Lo2)
+		grid.fixSigned()
 		g = &grid
 	case 90:
 		var grid Grid90
-		err := binary.Read(f, binary.BigEndian, &grid)
-		Name="Space view perspective or orthographic"
-		return &grid, err
+		err = binary.Read(f, binary.BigEndian, &grid)
+		grid.Lap = signed32(grid.Lap)
+		grid.Lop = signed32(grid.Lop)
+		grid.fixSigned()
+		g = &grid
 
 	default:
 		var grid Grid90
-		return &grid, errors.New(fmt.Sprint("Unsupported grid definition ", templateNumber))
+		return &grid, fmt.Errorf("%w: шаблон сетки 3.%d", ErrUnsupportedTemplate, templateNumber)
 	}
 	return g, err
 }
@@ -83,6 +106,13 @@ type GridHeader struct {
 	MinorAxis       ScaledValue `json:"minorAxis"`
 }
 
+// fixSigned Переводит масштабные множители радиуса и осей Земли из прямого кода
+func (h *GridHeader) fixSigned() {
+	h.SphericalRadius.Scale = signed8(h.SphericalRadius.Scale)
+	h.MajorAxis.Scale = signed8(h.MajorAxis.Scale)
+	h.MinorAxis.Scale = signed8(h.MinorAxis.Scale)
+}
+
 func (h *GridHeader) Export() (d map[string]string) {
 	return map[string]string{
 		"earth": EarthShapeDescription(int(h.EarthShape)),
@@ -90,7 +120,7 @@ func (h *GridHeader) Export() (d map[string]string) {
 }
 
 type ScaledValue struct {
-	Scale uint8  `json:"scale"`
+	Scale int8   `json:"scale"`
 	Value uint32 `json:"value"`
 }
 
@@ -203,27 +233,27 @@ type Grid30 struct {
 	Dy                          int32  `json:"dy"`
 	ProjectionCenter            uint8  `json:"projectionCenter"`
 	ScanningMode                uint8  `json:"scanningMode"`
-	Latin1                      uint32 `json:"latin1"`
-	Latin2                      uint32 `json:"latin2"`
-	LaSouthPole                 uint32 `json:"laSouthPole"`
-	LoSouthPole                 uint32 `json:"loSouthPole"`
+	Latin1                      int32  `json:"latin1"`
+	Latin2                      int32  `json:"latin2"`
+	LaSouthPole                 int32  `json:"laSouthPole"`
+	LoSouthPole                 int32  `json:"loSouthPole"`
 }
 
 // Grid40 Definition Template 3.40: Gaussian latitude/longitude
 type Grid40 struct {
 	// Name 						string `json:"name"`//name =  "Gaussian latitude/longitude ";
 	GridHeader
-	Ni                          uint32 `json:"ni"`
-	Nj                          uint32 `json:"nj"`
-	BasicAngle                  uint32 `json:"basicAngle"`
-	La1                         int32  `json:"la1"`
-	Lo1                         int32  `json:"lo1"`
-	ResolutionAndComponentFlags uint8  `json:"resolutionAndComponentFlags"`
-	La2                         int32  `json:"la2"`
-	Lo2                         int32  `json:"lo2"`
-	Di                          int32  `json:"di"`
-	N                           uint32 `json:"n"`
-	ScanningMode                uint8  `json:"scanningMode"`
+	Ni                          uint32     `json:"ni"`
+	Nj                          uint32     `json:"nj"`
+	BasicAngle                  BasicAngle `json:"basicAngle"`
+	La1                         int32      `json:"la1"`
+	Lo1                         int32      `json:"lo1"`
+	ResolutionAndComponentFlags uint8      `json:"resolutionAndComponentFlags"`
+	La2                         int32      `json:"la2"`
+	Lo2                         int32      `json:"lo2"`
+	Di                          int32      `json:"di"`
+	N                           uint32     `json:"n"`
+	ScanningMode                uint8      `json:"scanningMode"`
 }
 
 // Grid90 Definition Template 3.90: Space view perspective or orthographic
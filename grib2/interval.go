@@ -0,0 +1,162 @@
+package grib2
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Коды типов статистической обработки (Code table 4.10)
+const (
+	ProcessAverage      uint8 = 0
+	ProcessAccumulation uint8 = 1
+	ProcessMaximum      uint8 = 2
+	ProcessMinimum      uint8 = 3
+	// ProcessNone Поле не подвергалось статистической обработке (мгновенное значение)
+	ProcessNone uint8 = 255
+)
+
+// stepTypes Названия типов статистической обработки в ecCodes
+var stepTypes = map[uint8]string{
+	0: "avg",
+	1: "accum",
+	2: "max",
+	3: "min",
+	4: "diff",
+	5: "rms",
+	6: "sd",
+	7: "cov",
+	8: "sdiff",
+	9: "ratio",
+}
+
+// Interval Интервал статистической обработки из шаблонов 4.8 и 4.11. Начало интервала задается
+// временем прогноза шаблона 4.0, конец — полем End
+type Interval struct {
+	End          Time                     `json:"end"`          // Конец всего интервала
+	Count        uint8                    `json:"count"`        // Количество описаний интервалов
+	MissingCount uint32                   `json:"missingCount"` // Количество пропущенных значений при обработке
+	Ranges       []TimeRangeSpecification `json:"ranges"`       // Описания интервалов, первое — внешний интервал
+}
+
+// readInterval Читает описание интервала статистической обработки
+func readInterval(f io.Reader) (*Interval, error) {
+	interval := &Interval{}
+	if err := read(f, &interval.End, &interval.Count, &interval.MissingCount); err != nil {
+		return nil, err
+	}
+	if interval.Count == 0 {
+		return nil, fmt.Errorf("%w: нет описаний интервалов статистической обработки", ErrCorruptData)
+	}
+	interval.Ranges = make([]TimeRangeSpecification, interval.Count)
+	if err := read(f, &interval.Ranges); err != nil {
+		return nil, err
+	}
+	return interval, nil
+}
+
+// Process Возвращает тип статистической обработки внешнего интервала (Code table 4.10)
+func (interval *Interval) Process() uint8 {
+	if interval == nil || len(interval.Ranges) == 0 {
+		return ProcessNone
+	}
+	return interval.Ranges[0].StatisticalFieldCalculationProcess
+}
+
+// StepType Возвращает название типа статистической обработки в ecCodes: "accum", "avg", "max", "min"
+// и т.д. Для полей без статистической обработки возвращает "instant"
+func StepType(process uint8) string {
+	if process == ProcessNone {
+		return "instant"
+	}
+	if stepType, ok := stepTypes[process]; ok {
+		return stepType
+	}
+	return itoa(process)
+}
+
+// Window Возвращает начало и конец интервала статистической обработки. Для полей без обработки
+// оба равны времени действия прогноза. Второе значение false, если время прогноза не переводится
+// в длительность
+func (m *Message) Window() (time.Time, time.Time, bool) {
+	product := m.Section4.ProductDefinitionTemplate
+	unit, ok := timeUnitDuration(product.TimeUnitIndicator)
+	if !ok {
+		return m.ReferenceTime(), m.ReferenceTime(), false
+	}
+	start := m.ReferenceTime().Add(time.Duration(product.ForecastTime) * unit)
+	if m.Section4.Interval == nil {
+		return start, start, true
+	}
+	end := m.Section4.Interval.End
+	return start, time.Date(int(end.Year), time.Month(end.Month), int(end.Day), int(end.Hour), int(end.Minute), int(end.Second), 0, time.UTC), true
+}
+
+// step Возвращает время от срока прогноза до момента at в единицах времени прогноза поля.
+// Единица без постоянной длительности и время, не кратное единице, считаются ошибкой
+func (t *Table) step(at time.Time) (int32, error) {
+	unit, ok := timeUnitDuration(t.timeUnit)
+	if !ok {
+		return 0, fmt.Errorf("единица времени прогноза %d не имеет постоянной длительности", t.timeUnit)
+	}
+	offset := at.Sub(t.Date)
+	if offset%unit != 0 {
+		return 0, fmt.Errorf("время %s от срока прогноза не кратно единице времени прогноза %s", offset, unit)
+	}
+	return int32(offset / unit), nil
+}
+
+// Deaccumulate Переводит суммы, накопленные от общего начала интервала (обычно от начала прогноза),
+// в суммы за интервалы между соседними шагами: 0-3, 0-6, 0-9 -> 0-3, 3-6, 6-9. Поля должны относиться
+// к одному параметру, поверхности и сроку, иметь тип обработки "накопление" и одинаковый размер,
+// а начала новых интервалов — выражаться целым числом единиц времени прогноза исходных полей.
+// Исходные поля не изменяются, первое поле возвращается как есть
+func Deaccumulate(fields []*Table) ([]*Table, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	sorted := append([]*Table(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].WindowEnd.Before(sorted[j].WindowEnd) })
+	first := sorted[0]
+	for _, field := range sorted {
+		if field.StatisticalProcess != ProcessAccumulation {
+			return nil, fmt.Errorf("поле %s не является накоплением", field.Parameter.ShortName)
+		}
+		if field.Parameter.Discipline != first.Parameter.Discipline || field.Parameter.Category != first.Parameter.Category ||
+			field.Parameter.Number != first.Parameter.Number || field.SurfaceType != first.SurfaceType || field.SurfaceValue != first.SurfaceValue {
+			return nil, fmt.Errorf("поля %s и %s относятся к разным параметрам или поверхностям", first.Parameter.ShortName, field.Parameter.ShortName)
+		}
+		if !field.Date.Equal(first.Date) || !field.WindowStart.Equal(first.WindowStart) {
+			return nil, fmt.Errorf("поля %s накоплены от разного начала интервала", first.Parameter.ShortName)
+		}
+		if len(field.Data) != len(first.Data) {
+			return nil, fmt.Errorf("поля %s имеют разный размер: %d и %d", first.Parameter.ShortName, len(first.Data), len(field.Data))
+		}
+	}
+	result := []*Table{first}
+	for i := 1; i < len(sorted); i++ {
+		previous, current := sorted[i-1], sorted[i]
+		if !current.WindowEnd.After(previous.WindowEnd) {
+			return nil, fmt.Errorf("поля %s с одинаковым концом интервала %s", first.Parameter.ShortName, current.WindowEnd)
+		}
+		data := make(Values, len(current.Data))
+		for j := range data {
+			if IsMissing(current.Data[j]) || IsMissing(previous.Data[j]) {
+				data[j] = MissingValue
+				continue
+			}
+			data[j] = current.Data[j] - previous.Data[j]
+		}
+		// Время прогноза производного поля указывает на начало его интервала в единицах исходного поля
+		step, err := current.step(previous.WindowEnd)
+		if err != nil {
+			return nil, fmt.Errorf("поле %s: %w", first.Parameter.ShortName, err)
+		}
+		interval := current.derive(data)
+		interval.WindowStart = previous.WindowEnd
+		interval.ForecastTime = step
+		result = append(result, interval)
+	}
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package grib2
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// intervalTemplate Записывает интервал шаблона 4.8 с концом end и описаниями интервалов с типами
+// обработки processes в единицах unit длиной length
+func intervalTemplate(end time.Time, unit uint8, length uint32, processes ...uint8) []byte {
+	data := bigEndian(uint16(end.Year()), uint8(end.Month()), uint8(end.Day()), uint8(end.Hour()), uint8(end.Minute()), uint8(end.Second()),
+		uint8(len(processes)), uint32(0))
+	for _, process := range processes {
+		data = append(data, bigEndian(process, uint8(2), unit, length, uint8(255), uint32(0))...)
+	}
+	return data
+}
+
+func TestInterval(t *testing.T) {
+	date := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		minutes    bool
+		forecast   uint32
+		interval   []byte
+		process    uint8
+		stepType   string
+		start, end time.Duration
+		stepRange  string
+	}{
+		{"мгновенное поле", false, 6, nil, ProcessNone, "instant", 6 * time.Hour, 6 * time.Hour, "6"},
+		{"накопление 0-6 ч", false, 0, intervalTemplate(date.Add(6*time.Hour), 1, 6, ProcessAccumulation), ProcessAccumulation, "accum", 0, 6 * time.Hour, "0-6"},
+		{"среднее 6-12 ч", false, 6, intervalTemplate(date.Add(12*time.Hour), 1, 6, ProcessAverage), ProcessAverage, "avg", 6 * time.Hour, 12 * time.Hour, "6-12"},
+		{"максимум 12-18 ч", false, 12, intervalTemplate(date.Add(18*time.Hour), 1, 6, ProcessMaximum), ProcessMaximum, "max", 12 * time.Hour, 18 * time.Hour, "12-18"},
+		{"минимум 0-24 ч", false, 0, intervalTemplate(date.Add(24*time.Hour), 1, 24, ProcessMinimum), ProcessMinimum, "min", 0, 24 * time.Hour, "0-24"},
+		{"накопление в минутах", true, 30, intervalTemplate(date.Add(45*time.Minute), 0, 15, ProcessAccumulation), ProcessAccumulation, "accum", 30 * time.Minute, 45 * time.Minute, "30m-45m"},
+		{"накопление 90 минут", true, 0, intervalTemplate(date.Add(90*time.Minute), 0, 90, ProcessAccumulation), ProcessAccumulation, "accum", 0, 90 * time.Minute, "0-90m"},
+		// Тип обработки берется из первого, внешнего интервала
+		{"максимум средних", false, 0, intervalTemplate(date.Add(24*time.Hour), 1, 24, ProcessMaximum, ProcessAverage), ProcessMaximum, "max", 0, 24 * time.Hour, "0-24"},
+		{"разность", false, 0, intervalTemplate(date.Add(3*time.Hour), 1, 3, 4), 4, "diff", 0, 3 * time.Hour, "0-3"},
+		{"код центра", false, 0, intervalTemplate(date.Add(3*time.Hour), 1, 3, 200), 200, "200", 0, 3 * time.Hour, "0-3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, err := decodeBytes(t, testMessage{
+				ni:       2,
+				nj:       1,
+				forecast: test.forecast,
+				minutes:  test.minutes,
+				interval: test.interval,
+				data:     bigEndian(float32(0), uint16(0), uint16(0), uint8(8), uint8(0)),
+				points:   2,
+				values:   []byte{0, 1},
+			}.encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			start, end, ok := message.Window()
+			if !ok || !start.Equal(date.Add(test.start)) || !end.Equal(date.Add(test.end)) {
+				t.Fatalf("интервал %s - %s, ожидался %s - %s", start, end, date.Add(test.start), date.Add(test.end))
+			}
+			if stepRange, _ := message.Get("stepRange"); stepRange != test.stepRange {
+				t.Fatalf("stepRange %q, ожидалось %q", stepRange, test.stepRange)
+			}
+			field := newTable(message)
+			if field.StatisticalProcess != test.process || field.StepType != test.stepType {
+				t.Fatalf("обработка %d (%s), ожидалась %d (%s)", field.StatisticalProcess, field.StepType, test.process, test.stepType)
+			}
+			if !field.WindowStart.Equal(start) || !field.WindowEnd.Equal(end) {
+				t.Fatalf("интервал поля %s - %s, ожидался %s - %s", field.WindowStart, field.WindowEnd, start, end)
+			}
+		})
+	}
+
+	// Шаблон 4.8 без описаний интервалов
+	_, err := decodeBytes(t, testMessage{
+		ni:       2,
+		nj:       1,
+		interval: intervalTemplate(date, 1, 0),
+		data:     bigEndian(float32(0), uint16(0), uint16(0), uint8(8), uint8(0)),
+		points:   2,
+		values:   []byte{0, 1},
+	}.encode())
+	if !errors.Is(err, ErrCorruptData) {
+		t.Fatalf("ошибка %v, ожидалась %v", err, ErrCorruptData)
+	}
+}
+
+// accumulation Интервал накопления в часах от начала прогноза и значения поля
+type accumulation struct {
+	start, end int
+	data       Values
+}
+
+func TestDeaccumulate(t *testing.T) {
+	date := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		unit   uint8
+		fields []accumulation
+		want   []accumulation
+		steps  []int32 // Время прогноза полей результата в единицах unit (Code table 4.4)
+		err    bool
+	}{
+		{
+			"поля не по порядку",
+			1,
+			[]accumulation{{0, 6, Values{9, 9}}, {0, 3, Values{3, 4}}, {0, 9, Values{12, 10}}},
+			[]accumulation{{0, 3, Values{3, 4}}, {3, 6, Values{6, 5}}, {6, 9, Values{3, 1}}},
+			[]int32{0, 3, 6},
+			false,
+		},
+		{
+			"точка без значения",
+			1,
+			[]accumulation{{0, 3, Values{3, MissingValue}}, {0, 6, Values{MissingValue, 9}}, {0, 9, Values{12, 12}}},
+			[]accumulation{{0, 3, Values{3, MissingValue}}, {3, 6, Values{MissingValue, MissingValue}}, {6, 9, Values{MissingValue, 3}}},
+			[]int32{0, 3, 6},
+			false,
+		},
+		{
+			"время прогноза в минутах",
+			0,
+			[]accumulation{{0, 1, Values{1, 1}}, {0, 2, Values{3, 3}}},
+			[]accumulation{{0, 1, Values{1, 1}}, {1, 2, Values{2, 2}}},
+			[]int32{0, 60},
+			false,
+		},
+		{
+			"время прогноза в шестичасовых единицах",
+			11,
+			[]accumulation{{0, 6, Values{1, 1}}, {0, 12, Values{3, 3}}},
+			[]accumulation{{0, 6, Values{1, 1}}, {6, 12, Values{2, 2}}},
+			[]int32{0, 1},
+			false,
+		},
+		{"начало интервала не кратно единице", 11, []accumulation{{0, 3, Values{1, 1}}, {0, 6, Values{3, 3}}}, nil, nil, true},
+		{"одинаковый конец интервала", 1, []accumulation{{0, 3, Values{1, 1}}, {0, 3, Values{2, 2}}}, nil, nil, true},
+		{"разное начало интервала", 1, []accumulation{{0, 3, Values{1, 1}}, {3, 6, Values{2, 2}}}, nil, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := make([]*Table, len(test.fields))
+			for k, step := range test.fields {
+				fields[k] = seriesField(t, date, ProcessAccumulation, step.start, step.end, 0)
+				fields[k].Data = step.data
+				fields[k].timeUnit = test.unit
+			}
+			result, err := Deaccumulate(fields)
+			if (err != nil) != test.err {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if len(result) != len(test.want) {
+				t.Fatalf("получено %d полей, ожидалось %d", len(result), len(test.want))
+			}
+			for k, want := range test.want {
+				field := result[k]
+				start, end := int(field.WindowStart.Sub(date)/time.Hour), int(field.WindowEnd.Sub(date)/time.Hour)
+				if start != want.start || end != want.end || !sameValues(field.Data, want.data) {
+					t.Fatalf("поле %d: %d-%d %v, ожидалось %d-%d %v", k, start, end, field.Data, want.start, want.end, want.data)
+				}
+				if field.ForecastTime != test.steps[k] {
+					t.Fatalf("поле %d-%d: время прогноза %d, ожидалось %d", start, end, field.ForecastTime, test.steps[k])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// messageKeys Вычисляемые ключи сообщения в стиле ecCodes. Ключи секций 0-4 доступны сразу после
+// чтения Секции 4, ключи секций 5-7 — после чтения соответствующей секции
+var messageKeys = map[string]func(m *Message) (string, bool){
+	// Секции 0 и 1
+	"editionNumber": func(m *Message) (string, bool) { return itoa(m.Section0.Edition), true },
+	"discipline":    func(m *Message) (string, bool) { return itoa(m.Section0.Discipline), true },
+	"centre":        func(m *Message) (string, bool) { return itoa(m.Section1.OriginatingCenter), true },
+	"subCentre":     func(m *Message) (string, bool) { return itoa(m.Section1.OriginatingSubCenter), true },
+	"tablesVersion": func(m *Message) (string, bool) { return itoa(m.Section1.MasterTablesVersion), true },
+	"localTablesVersion": func(m *Message) (string, bool) {
+		return itoa(m.Section1.LocalTablesVersion), true
+	},
+	"significanceOfReferenceTime": func(m *Message) (string, bool) {
+		return itoa(m.Section1.ReferenceTimeSignificance), true
+	},
+	"productionStatusOfProcessedData": func(m *Message) (string, bool) {
+		return itoa(m.Section1.ProductionStatus), true
+	},
+	"typeOfProcessedData": func(m *Message) (string, bool) { return itoa(m.Section1.Type), true },
+	"dataDate":            func(m *Message) (string, bool) { return m.ReferenceTime().Format("20060102"), true },
+	"dataTime":            func(m *Message) (string, bool) { return m.ReferenceTime().Format("1504"), true },
+	"validityDate": func(m *Message) (string, bool) {
+		validity, ok := m.ValidityTime()
+		return validity.Format("20060102"), ok
+	},
+	"validityTime": func(m *Message) (string, bool) {
+		validity, ok := m.ValidityTime()
+		return validity.Format("1504"), ok
+	},
+	// Секция 3
+	"gridDefinitionTemplateNumber": func(m *Message) (string, bool) {
+		return itoa(m.Section3.TemplateNumber), m.Section3.Definition != nil
+	},
+	"gridType": func(m *Message) (string, bool) {
+		gridType, ok := gridTypes[m.Section3.TemplateNumber]
+		return gridType, ok && m.Section3.Definition != nil
+	},
+	"numberOfDataPoints": func(m *Message) (string, bool) {
+		return itoa(m.Section3.DataPointCount), m.Section3.Definition != nil
+	},
+	"Ni": func(m *Message) (string, bool) {
+		ni, _, ok := gridSize(m.Section3.Definition)
+		return itoa(ni), ok
+	},
+	"Nj": func(m *Message) (string, bool) {
+		_, nj, ok := gridSize(m.Section3.Definition)
+		return itoa(nj), ok
+	},
+	// Секция 4
+	"productDefinitionTemplateNumber": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplateNumber), true
+	},
+	"parameterCategory": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.ParameterCategory), true
+	},
+	"parameterNumber": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.ParameterNumber), true
+	},
+	"shortName":  func(m *Message) (string, bool) { return m.EcCodesName(), true },
+	"wgrib2Name": func(m *Message) (string, bool) { return m.Parameter().ShortName, true },
+	"name":       func(m *Message) (string, bool) { return m.Parameter().Name, true },
+	"units":      func(m *Message) (string, bool) { return m.Parameter().Unit, true },
+	"cfName": func(m *Message) (string, bool) {
+		standardName := m.Parameter().StandardName
+		return standardName, standardName != ""
+	},
+	"typeOfGeneratingProcess": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.ProcessType), true
+	},
+	"generatingProcessIdentifier": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.AnalysisProcess), true
+	},
+	"indicatorOfUnitOfTimeRange": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.TimeUnitIndicator), true
+	},
+	"forecastTime": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.ForecastTime), true
+	},
+	"step": func(m *Message) (string, bool) {
+		_, end := m.steps()
+		return end, true
+	},
+	"startStep": func(m *Message) (string, bool) {
+		start, _ := m.steps()
+		return start, true
+	},
+	"endStep": func(m *Message) (string, bool) {
+		_, end := m.steps()
+		return end, true
+	},
+	"stepRange": func(m *Message) (string, bool) {
+		start, end := m.steps()
+		if start == end {
+			return end, true
+		}
+		return start + "-" + end, true
+	},
+	"stepType": func(m *Message) (string, bool) { return StepType(m.Section4.Interval.Process()), true },
+	"number": func(m *Message) (string, bool) {
+		if m.Section4.Ensemble == nil {
+			return "", false
+		}
+		return itoa(m.Section4.Ensemble.Number), true
+	},
+	"perturbationNumber": func(m *Message) (string, bool) {
+		if m.Section4.Ensemble == nil {
+			return "", false
+		}
+		return itoa(m.Section4.Ensemble.Number), true
+	},
+	"typeOfEnsembleForecast": func(m *Message) (string, bool) {
+		if m.Section4.Ensemble == nil {
+			return "", false
+		}
+		return itoa(m.Section4.Ensemble.Type), true
+	},
+	"numberOfForecastsInEnsemble": func(m *Message) (string, bool) {
+		if m.Section4.Ensemble == nil {
+			return "", false
+		}
+		return itoa(m.Section4.Ensemble.Count), true
+	},
+	"typeOfFirstFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.FirstSurface.Type), true
+	},
+	"scaleFactorOfFirstFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.FirstSurface.Scale), true
+	},
+	"scaledValueOfFirstFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.FirstSurface.Value), true
+	},
+	"typeOfSecondFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.SecondSurface.Type), true
+	},
+	"scaleFactorOfSecondFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.SecondSurface.Scale), true
+	},
+	"scaledValueOfSecondFixedSurface": func(m *Message) (string, bool) {
+		return itoa(m.Section4.ProductDefinitionTemplate.SecondSurface.Value), true
+	},
+	"typeOfLevel": func(m *Message) (string, bool) { return m.TypeOfLevel(), true },
+	"level": func(m *Message) (string, bool) {
+		return levelValue(m.TypeOfLevel(), m.Section4.ProductDefinitionTemplate.FirstSurface)
+	},
+	"topLevel": func(m *Message) (string, bool) {
+		return levelValue(m.TypeOfLevel(), m.Section4.ProductDefinitionTemplate.FirstSurface)
+	},
+	"bottomLevel": func(m *Message) (string, bool) {
+		surface := m.Section4.ProductDefinitionTemplate.SecondSurface
+		if surface.Type == 255 {
+			surface = m.Section4.ProductDefinitionTemplate.FirstSurface
+		}
+		return levelValue(m.TypeOfLevel(), surface)
+	},
+	// Секции 5-7
+	"dataRepresentationTemplateNumber": func(m *Message) (string, bool) {
+		return itoa(m.Section5.DataTemplateNumber), m.Section5.Data != nil
+	},
+	"packingType": func(m *Message) (string, bool) {
+		packingType, ok := packingTypes[m.Section5.DataTemplateNumber]
+		return packingType, ok && m.Section5.Data != nil
+	},
+	"bitsPerValue": func(m *Message) (string, bool) {
+		packing, ok := m.Section5.Packing()
+		return itoa(packing.Bits), ok
+	},
+	"numberOfValues": func(m *Message) (string, bool) {
+		return itoa(m.Section5.PointsNumber), m.Section5.Data != nil
+	},
+	"bitmapPresent": func(m *Message) (string, bool) {
+		if m.Section6.Bitmap == nil {
+			return "", false
+		}
+		if m.Section6.BitmapIndicator == 255 {
+			return "0", true
+		}
+		return "1", true
+	},
+	"numberOfMissing": func(m *Message) (string, bool) {
+		return itoa(m.Section7.Missing), m.Section7.Data != nil
+	},
+}
+
+// gridTypes Названия шаблонов сетки в ecCodes
+var gridTypes = map[uint16]string{
+	0:  "regular_ll",
+	10: "mercator",
+	20: "polar_stereographic",
+	30: "lambert",
+	40: "regular_gg",
+	90: "space_view",
+}
+
+// packingTypes Названия шаблонов представления данных в ecCodes
+var packingTypes = map[uint16]string{
+	0: "grid_simple",
+	2: "grid_complex",
+	3: "grid_complex_spatial_differencing",
+}
+
+// levelTypes Названия типов поверхностей (Code table 4.5) в ecCodes
+var levelTypes = map[uint8]string{
+	1:   "surface",
+	2:   "cloudBase",
+	3:   "cloudTop",
+	4:   "isothermZero",
+	5:   "adiabaticCondensation",
+	6:   "maxWind",
+	7:   "tropopause",
+	8:   "nominalTop",
+	9:   "seaBottom",
+	10:  "entireAtmosphere",
+	20:  "isothermal",
+	101: "meanSea",
+	102: "heightAboveSea",
+	103: "heightAboveGround",
+	104: "sigma",
+	105: "hybrid",
+	106: "depthBelowLand",
+	107: "theta",
+	108: "pressureFromGround",
+	109: "potentialVorticity",
+	111: "eta",
+	114: "snow",
+	117: "mixedLayerDepth",
+	150: "generalVertical",
+	151: "soil",
+	160: "depthBelowSea",
+	200: "atmosphere",
+	204: "highestTroposphericFreezing",
+	211: "boundaryLayerCloudLayer",
+	212: "lowCloudBottom",
+	213: "lowCloudTop",
+	214: "lowCloudLayer",
+	220: "planetaryBoundaryLayer",
+	222: "middleCloudBottom",
+	223: "middleCloudTop",
+	224: "middleCloudLayer",
+	232: "highCloudBottom",
+	233: "highCloudTop",
+	234: "highCloudLayer",
+	242: "convectiveCloudBottom",
+	243: "convectiveCloudTop",
+	244: "convectiveCloudLayer",
+}
+
+// layerTypes Названия слоев между двумя поверхностями одного типа
+var layerTypes = map[uint8]string{
+	100: "isobaricLayer",
+	103: "heightAboveGroundLayer",
+	104: "sigmaLayer",
+	105: "hybridLayer",
+	106: "depthBelowLandLayer",
+	107: "thetaLayer",
+	108: "pressureFromGroundLayer",
+}
+
+// surfaceName Параметр на поверхности, для которой в ecCodes есть отдельное короткое имя
+type surfaceName struct {
+	parameter   parameterKey
+	typeOfLevel string
+	level       string
+}
+
+// surfaceNames Короткие имена ecCodes, зависящие от поверхности: температура на 2 м — 2t, ветер
+// на 10 м — 10u, давление на уровне моря — msl
+var surfaceNames = map[surfaceName]string{
+	{parameterKey{0, 0, 0}, "heightAboveGround", "2"}:  "2t",
+	{parameterKey{0, 0, 4}, "heightAboveGround", "2"}:  "mx2t",
+	{parameterKey{0, 0, 5}, "heightAboveGround", "2"}:  "mn2t",
+	{parameterKey{0, 0, 6}, "heightAboveGround", "2"}:  "2d",
+	{parameterKey{0, 1, 0}, "heightAboveGround", "2"}:  "2sh",
+	{parameterKey{0, 1, 1}, "heightAboveGround", "2"}:  "2r",
+	{parameterKey{0, 2, 0}, "heightAboveGround", "10"}: "10wdir",
+	{parameterKey{0, 2, 1}, "heightAboveGround", "10"}: "10si",
+	{parameterKey{0, 2, 2}, "heightAboveGround", "10"}: "10u",
+	{parameterKey{0, 2, 3}, "heightAboveGround", "10"}: "10v",
+	{parameterKey{0, 3, 0}, "surface", "0"}:            "sp",
+	{parameterKey{0, 3, 1}, "meanSea", "0"}:            "msl",
+}
+
+// EcCodesName Возвращает короткое имя параметра в ecCodes с учетом поверхности, например "t"
+// или "2t". Для параметров без имени в ecCodes возвращается "unknown", как в ecCodes
+func (m *Message) EcCodesName() string {
+	parameter := m.Parameter()
+	typeOfLevel := m.TypeOfLevel()
+	level, _ := levelValue(typeOfLevel, m.Section4.ProductDefinitionTemplate.FirstSurface)
+	key := surfaceName{parameterKey{parameter.Discipline, parameter.Category, parameter.Number}, typeOfLevel, level}
+	if name, ok := surfaceNames[key]; ok {
+		return name
+	}
+	if parameter.EcCodesName == "" {
+		return "unknown"
+	}
+	return parameter.EcCodesName
+}
+
+// Get Возвращает значение ключа в стиле ecCodes, например "shortName", "level", "typeOfLevel",
+// "stepRange", "dataDate" или "gridType". Второе значение false, если ключ неизвестен или
+// соответствующая секция еще не прочитана
+func (m *Message) Get(key string) (string, bool) {
+	get, ok := messageKeys[key]
+	if !ok {
+		return "", false
+	}
+	return get(m)
+}
+
+// Keys Возвращает отсортированный список ключей, доступных через Get
+func Keys() []string {
+	keys := make([]string, 0, len(messageKeys))
+	for key := range messageKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Parameter Возвращает описание параметра сообщения
+func (m *Message) Parameter() Parameter {
+	product := m.Section4.ProductDefinitionTemplate
+	return LookupParameter(m.Section1, m.Section0.Discipline, product.ParameterCategory, product.ParameterNumber)
+}
+
+// ReferenceTime Возвращает исходное время из Секции 1
+func (m *Message) ReferenceTime() time.Time {
+	t := m.Section1.ReferenceTime
+	return time.Date(int(t.Year), time.Month(t.Month), int(t.Day), int(t.Hour), int(t.Minute), int(t.Second), 0, time.UTC)
+}
+
+// ValidityTime Возвращает время, на которое действителен прогноз, для статистически обработанных
+// полей — конец интервала. Второе значение false, если единица времени прогноза не переводится
+// в длительность (месяцы, годы)
+func (m *Message) ValidityTime() (time.Time, bool) {
+	_, end, ok := m.Window()
+	return end, ok
+}
+
+// TypeOfLevel Возвращает название типа поверхности в ecCodes. Изобарические поверхности называются
+// isobaricInhPa, если давление кратно 100 Па, иначе isobaricInPa. Для неизвестных типов возвращается номер
+func (m *Message) TypeOfLevel() string {
+	first := m.Section4.ProductDefinitionTemplate.FirstSurface
+	second := m.Section4.ProductDefinitionTemplate.SecondSurface
+	if second.Type == first.Type {
+		if name, ok := layerTypes[first.Type]; ok {
+			return name
+		}
+	}
+	if first.Type == 100 {
+		if pressure, ok := first.Float(); ok && pressure != float64(int64(pressure/100))*100 {
+			return "isobaricInPa"
+		}
+		return "isobaricInhPa"
+	}
+	if name, ok := levelTypes[first.Type]; ok {
+		return name
+	}
+	return itoa(first.Type)
+}
+
+// steps Возвращает начало и конец интервала прогноза в часах или, если они не выражаются целым
+// числом часов, с суффиксом единицы измерения: "30m", "90s". Для мгновенных полей они совпадают
+func (m *Message) steps() (string, string) {
+	start, end, ok := m.Window()
+	if !ok {
+		step := itoa(m.Section4.ProductDefinitionTemplate.ForecastTime)
+		return step, step
+	}
+	return formatStep(start.Sub(m.ReferenceTime())), formatStep(end.Sub(m.ReferenceTime()))
+}
+
+// formatStep Записывает длительность в часах, минутах или секундах
+func formatStep(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return itoa(int64(d / time.Hour))
+	case d%time.Minute == 0:
+		return itoa(int64(d/time.Minute)) + "m"
+	default:
+		return itoa(int64(d/time.Second)) + "s"
+	}
+}
+
+// timeUnitDuration Возвращает длительность единицы времени (Code table 4.4). Месяцы, годы
+// и неизвестные единицы не имеют постоянной длительности
+func timeUnitDuration(unit uint8) (time.Duration, bool) {
+	switch unit {
+	case 0:
+		return time.Minute, true
+	case 1:
+		return time.Hour, true
+	case 2:
+		return 24 * time.Hour, true
+	case 10:
+		return 3 * time.Hour, true
+	case 11:
+		return 6 * time.Hour, true
+	case 12:
+		return 12 * time.Hour, true
+	case 13:
+		return time.Second, true
+	}
+	return 0, false
+}
+
+// levelValue Возвращает значение поверхности в единицах ecCodes: давление в гПа для
+// изобарических поверхностей и слоев, остальные поверхности в единицах Code table 4.5
+func levelValue(typeOfLevel string, surface Surface) (string, bool) {
+	value, ok := surface.Float()
+	if !ok {
+		return "0", true
+	}
+	switch typeOfLevel {
+	case "isobaricInhPa", "isobaricLayer", "pressureFromGroundLayer":
+		value /= 100
+	}
+	// Масштабный множитель дает ошибки округления вида 0.30000000000000004
+	value = math.Round(value*1e9) / 1e9
+	return strconv.FormatFloat(value, 'f', -1, 64), true
+}
+
+// gridSize Возвращает количество точек сетки вдоль параллели и меридиана
+func gridSize(definition interface{}) (uint32, uint32, bool) {
+	switch grid := definition.(type) {
+	case *Grid0:
+		return grid.Ni, grid.Nj, true
+	case *Grid10:
+		return grid.Ni, uint32(grid.Nj), true
+	case *Grid20:
+		return grid.Nx, grid.Ny, true
+	case *Grid30:
+		return grid.Nx, grid.Ny, true
+	case *Grid40:
+		return grid.Ni, grid.Nj, true
+	case *Grid90:
+		return grid.Nx, grid.Ny, true
+	}
+	return 0, 0, false
+}
+
+// itoa Записывает целое число в десятичном виде
+func itoa[T int | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32](value T) string {
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,55 @@
+package grib2
+
+import "testing"
+
+func TestShortNameFilter(t *testing.T) {
+	message, err := decodeBytes(t, simpleMessage(2, 1, []uint64{0, 1}, 8, 250, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		discipline uint8
+		category   uint8
+		number     uint8
+		surface    Surface
+		shortName  string
+		filter     string
+	}{
+		{"температура на 850 гПа", 0, 0, 0, Surface{100, 0, 85000}, "t", "shortName=t,level=850"},
+		{"температура на 2 м", 0, 0, 0, Surface{103, 0, 2}, "2t", "shortName=2t"},
+		{"ветер на 10 м", 0, 2, 2, Surface{103, 0, 10}, "10u", "shortName=10u/10v,typeOfLevel=heightAboveGround"},
+		{"ветер на 850 гПа", 0, 2, 3, Surface{100, 0, 85000}, "v", "shortName=u/v"},
+		{"геопотенциал", 0, 3, 5, Surface{100, 0, 50000}, "gh", "shortName=gh,level=500"},
+		{"давление на уровне моря", 0, 3, 1, Surface{101, 0, 0}, "msl", "shortName=msl"},
+		{"параметр без имени в ecCodes", 0, 0, 9, Surface{100, 0, 85000}, "unknown", "wgrib2Name=TMPA"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := *message
+			field.Section0.Discipline = test.discipline
+			field.Section4.ProductDefinitionTemplate.ParameterCategory = test.category
+			field.Section4.ProductDefinitionTemplate.ParameterNumber = test.number
+			field.Section4.ProductDefinitionTemplate.FirstSurface = test.surface
+			if name, _ := field.Get("shortName"); name != test.shortName {
+				t.Fatalf("shortName %q, ожидалось %q", name, test.shortName)
+			}
+			filter, err := ParseFilter(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !filter.Match(&field) {
+				t.Fatalf("фильтр %q не выбрал сообщение", test.filter)
+			}
+		})
+	}
+
+	// Имя в стиле wgrib2 остается доступным по отдельному ключу и не совпадает с shortName
+	if name, _ := message.Get("wgrib2Name"); name != "TMP" {
+		t.Fatalf("wgrib2Name %q, ожидалось TMP", name)
+	}
+	filter, _ := ParseFilter("shortName=TMP")
+	if filter.Match(message) {
+		t.Fatal("shortName=TMP выбрал сообщение: shortName должен возвращать имя ecCodes")
+	}
+}
@@ -0,0 +1,59 @@
+package grib2
+
+import (
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalLegend(t *testing.T) {
+	bundled, err := fs.Sub(bundledTables, "tables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Локальная таблица NCEP переопределяет описания кодов таблицы ВМО 4.222
+	local := fstest.MapFS{"local/7/1/4.222.csv": {Data: []byte("code,description\n0,No rain\n1,Rain\n")}}
+	registry, err := NewTableRegistry(bundled, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(tables *TableRegistry) { Tables = tables }(Tables)
+	Tables = registry
+
+	message, err := decodeBytes(t, simpleMessage(2, 2, []uint64{0, 1, 1, 0}, 1, 0, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	message.Section4.ProductDefinitionTemplate.ParameterCategory = 1
+	message.Section4.ProductDefinitionTemplate.ParameterNumber = 192
+	field := newTable(message)
+	want := map[string]string{"0": "No rain", "1": "Rain"}
+	if field.Parameter.ShortName != "CRAIN" || !reflect.DeepEqual(field.Legend, want) {
+		t.Fatalf("%s: легенда %v, ожидалась %v", field.Parameter.ShortName, field.Legend, want)
+	}
+
+	region, err := ParseRegion("0,59,1,60")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		apply func(*Table) (*Table, error)
+		want  map[string]string
+	}{
+		{"новые значения", func(field *Table) (*Table, error) { return field.derive(Values{1, 1, 1, 1}), nil }, map[string]string{"1": "Rain"}},
+		{"область", func(field *Table) (*Table, error) { return field.Crop(region) }, want},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.apply(field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Legend, test.want) {
+				t.Fatalf("легенда %v, ожидалась %v", result.Legend, test.want)
+			}
+		})
+	}
+}
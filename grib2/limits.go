@@ -0,0 +1,30 @@
+package grib2
+
+import (
+	"fmt"
+)
+
+// Limits Ограничения на размеры структур, прочитанных из заголовков сообщения.
+// Проверяются до выделения памяти, чтобы поврежденный или враждебный файл
+// не мог заставить процесс выделить гигабайты памяти
+type Limits struct {
+	MaxMessageSize uint64 // Максимальная длина сообщения из Секции 0 в байтах
+	MaxPoints      uint64 // Максимальное количество точек поля (Секции 3 и 5)
+	MaxGroups      uint64 // Максимальное количество групп при сложной упаковке (NG)
+}
+
+// DecodeLimits Действующие ограничения декодера. Значения по умолчанию с запасом покрывают
+// глобальные поля с шагом 0.1° (6.5 млн точек)
+var DecodeLimits = Limits{
+	MaxMessageSize: 1 << 30,
+	MaxPoints:      100000000,
+	MaxGroups:      10000000,
+}
+
+// checkLimit Возвращает ошибку ErrLimitExceeded, если значение превышает ограничение
+func checkLimit(what string, value uint64, limit uint64) error {
+	if limit != 0 && value > limit {
+		return fmt.Errorf("%w: %s %d, допустимо не более %d", ErrLimitExceeded, what, value, limit)
+	}
+	return nil
+}
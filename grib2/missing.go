@@ -0,0 +1,88 @@
+package grib2
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	// MissingValue Значение, записываемое в точки без данных (по битовой карте или пропуски сложной упаковки)
+	MissingValue = math.NaN()
+	// UseMissingSubstitutes Записывать в пропуски сложной упаковки заменители из Секции 5 вместо MissingValue
+	UseMissingSubstitutes bool
+)
+
+// IsMissing Сообщает, что значение обозначает точку без данных: NaN или MissingValue
+func IsMissing(value float64) bool {
+	return math.IsNaN(value) || value == MissingValue
+}
+
+// Values Массив значений поля. В json пропуски (NaN) записываются как null
+type Values []float64
+
+// MarshalJSON Кодирует значения в json, заменяя NaN и бесконечности на null.
+// При Float32Values значения записываются кратчайшей записью числа одинарной точности
+func (v Values) MarshalJSON() ([]byte, error) {
+	bitSize := 64
+	if Float32Values {
+		bitSize = 32
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(v) * 8)
+	buf.WriteByte('[')
+	for i, value := range v {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			buf.WriteString("null")
+			continue
+		}
+		buf.WriteString(strconv.FormatFloat(value, 'g', -1, bitSize))
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// applyBitmap Расставляет декодированные значения по точкам сетки согласно битовой карте Секции 6.
+// Точки, отсутствующие в битовой карте, заполняются MissingValue
+func (section *Section7) applyBitmap(section6 Section6, points uint32) error {
+	if section6.BitmapIndicator != 0 {
+		return nil
+	}
+	if uint64(len(section6.Bitmap))*8 < uint64(points) {
+		return fmt.Errorf("%w: битовая карта на %d точек короче сетки из %d точек", ErrLengthMismatch, len(section6.Bitmap)*8, points)
+	}
+	expanded := make(Values, points)
+	var codes []int32
+	if section.Codes != nil {
+		codes = make([]int32, points)
+	}
+	n := 0
+	for i := uint32(0); i < points; i++ {
+		if section6.Bitmap[i/8]&(0x80>>(i%8)) == 0 {
+			expanded[i] = MissingValue
+			if codes != nil {
+				codes[i] = MissingCode
+			}
+			section.Missing++
+			continue
+		}
+		if n >= len(section.Data) {
+			return fmt.Errorf("%w: в битовой карте больше точек, чем декодировано значений (%d)", ErrLengthMismatch, len(section.Data))
+		}
+		expanded[i] = section.Data[n]
+		if codes != nil {
+			codes[i] = section.Codes[n]
+		}
+		n++
+	}
+	if n != len(section.Data) {
+		return fmt.Errorf("%w: в битовой карте %d точек, декодировано значений %d", ErrLengthMismatch, n, len(section.Data))
+	}
+	section.Data = expanded
+	section.Codes = codes
+	return nil
+}
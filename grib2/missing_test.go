@@ -0,0 +1,89 @@
+package grib2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMissingSubstitutes(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name        string
+		packing     complexPacking
+		substitutes bool
+		data        Values
+		missing     int
+	}{
+		{
+			name:    "без пропусков",
+			packing: complexPacking{bits: 8, groups: []testGroup{{10, 3, []uint64{1, 2, 3}}, {20, 0, make([]uint64, 2)}}},
+			data:    Values{111, 112, 113, 120, 120},
+		},
+		{
+			name:    "первичные пропуски",
+			packing: complexPacking{bits: 8, management: 1, groups: []testGroup{{10, 3, []uint64{1, 7, 3}}, {255, 0, make([]uint64, 2)}}},
+			data:    Values{111, nan, 113, nan, nan},
+			missing: 3,
+		},
+		{
+			name:    "первичные и вторичные пропуски",
+			packing: complexPacking{bits: 8, management: 2, groups: []testGroup{{10, 3, []uint64{6, 7, 3}}, {254, 0, make([]uint64, 2)}}},
+			data:    Values{nan, nan, 113, nan, nan},
+			missing: 4,
+		},
+		{
+			name: "заменители вещественного поля",
+			packing: complexPacking{bits: 8, management: 2, substitutes: [2]uint32{math.Float32bits(-999), math.Float32bits(-888.5)},
+				groups: []testGroup{{10, 3, []uint64{6, 7, 3}}, {254, 0, make([]uint64, 2)}}},
+			substitutes: true,
+			data:        Values{-888.5, -999, 113, -888.5, -888.5},
+			missing:     4,
+		},
+		{
+			name: "заменители целочисленного поля",
+			packing: complexPacking{bits: 8, management: 1, kind: 1, substitutes: [2]uint32{9999, 0},
+				groups: []testGroup{{10, 3, []uint64{1, 7, 3}}, {255, 0, make([]uint64, 2)}}},
+			substitutes: true,
+			data:        Values{111, 9999, 113, 9999, 9999},
+			missing:     3,
+		},
+		{
+			name:    "пропуски с дифференцированием",
+			packing: complexPacking{spatial: true, ival1: 5, bits: 8, management: 1, groups: []testGroup{{0, 3, []uint64{0, 7, 1}}, {255, 0, make([]uint64, 2)}}},
+			data:    Values{105, nan, 106, nan, nan},
+			missing: 3,
+		},
+	}
+	defer func(substitutes bool) { UseMissingSubstitutes = substitutes }(UseMissingSubstitutes)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			UseMissingSubstitutes = test.substitutes
+			message, err := decodeBytes(t, test.packing.encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameValues(message.Section7.Data, test.data) || message.Section7.Missing != test.missing {
+				t.Fatalf("значения %v, пропусков %d; ожидалось %v, %d", message.Section7.Data, message.Section7.Missing, test.data, test.missing)
+			}
+		})
+	}
+}
+
+func TestBitmapMissing(t *testing.T) {
+	message, err := decodeBytes(t, testMessage{
+		ni:       3,
+		nj:       2,
+		template: 0,
+		data:     bigEndian(float32(1), uint16(0), uint16(0), uint8(4), uint8(0)),
+		points:   4,
+		bitmap:   []byte{0, 0xb4},
+		values:   []byte{0x12, 0x34},
+	}.encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Values{2, math.NaN(), 3, 4, math.NaN(), 5}
+	if !sameValues(message.Section7.Data, want) || message.Section7.Missing != 2 {
+		t.Fatalf("значения %v, пропусков %d", message.Section7.Data, message.Section7.Missing)
+	}
+}
@@ -0,0 +1,32 @@
+package grib2
+
+import (
+	"math"
+)
+
+// StorePackedCodes Сохранять упакованные целые коды значений. Вместе с опорным значением R,
+// двоичным E и десятичным D масштабными множителями они позволяют точно восстановить
+// значение Y = (R + X * 2^E) / 10^D и заново упаковать поле без потери точности
+var StorePackedCodes = true
+
+// MissingCode Код, записываемый в точки без данных. Настоящие коды неотрицательны
+const MissingCode int32 = -1
+
+// packedCodes Переводит распакованные целые в коды для хранения, пропуски получают MissingCode.
+// При сложной упаковке код складывается из опорного значения группы и значения ширины группы,
+// а дифференцирование может расширить его еще, поэтому каждый код проверяется отдельно. Если хотя бы
+// один код не помещается в int32, коды поля не сохраняются
+func packedCodes(section7Data []int64, ifldmiss []int64) []int32 {
+	codes := make([]int32, len(section7Data))
+	for i, code := range section7Data {
+		if ifldmiss != nil && ifldmiss[i] != 0 {
+			codes[i] = MissingCode
+			continue
+		}
+		if code < 0 || code > math.MaxInt32 {
+			return nil
+		}
+		codes[i] = int32(code)
+	}
+	return codes
+}
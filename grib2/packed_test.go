@@ -0,0 +1,83 @@
+package grib2
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestPackedCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		message []byte
+		codes   []int32
+		data    Values // Значения, если их нельзя проверить по кодам
+	}{
+		{
+			name:    "простая упаковка",
+			message: simpleMessage(3, 1, []uint64{0, 5, 255}, 8, 250, 0x8001, 0x0001, 0),
+			codes:   []int32{0, 5, 255},
+		},
+		{
+			name: "битовая карта",
+			message: testMessage{ni: 3, nj: 2, data: bigEndian(float32(1), uint16(0), uint16(0), uint8(4), uint8(0)), points: 4,
+				bitmap: []byte{0, 0xb4}, values: []byte{0x12, 0x34}}.encode(),
+			codes: []int32{1, MissingCode, 2, 3, MissingCode, 4},
+		},
+		{
+			name:    "сложная упаковка с пропусками",
+			message: complexPacking{bits: 8, management: 1, groups: []testGroup{{10, 3, []uint64{1, 7, 3}}, {255, 0, make([]uint64, 2)}}}.encode(),
+			codes:   []int32{11, MissingCode, 13, MissingCode, MissingCode},
+		},
+		{
+			// Код складывается из опорного значения группы шириной 31 бит и значения шириной 15 бит
+			name:    "коды шире 31 бита",
+			message: complexPacking{bits: 31, groups: []testGroup{{1<<31 - 1, 15, []uint64{0, 1 << 14}}}}.encode(),
+			codes:   []int32{},
+			data:    Values{100 + 1<<31 - 1, 100 + 1<<31 - 1 + 1<<14},
+		},
+		{
+			name:    "постоянное поле",
+			message: simpleMessage(3, 1, make([]uint64, 3), 0, 250, 0, 0, 0),
+			codes:   []int32{0, 0, 0},
+		},
+	}
+	defer func(store bool) { StorePackedCodes = store }(StorePackedCodes)
+	StorePackedCodes = true
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, err := decodeBytes(t, test.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table := newTable(message)
+			if !reflect.DeepEqual(table.Data_int, test.codes) {
+				t.Fatalf("коды %v, ожидалось %v", table.Data_int, test.codes)
+			}
+			if test.data != nil && !sameValues(table.Data, test.data) {
+				t.Fatalf("значения %v, ожидалось %v", table.Data, test.data)
+			}
+			// Значение точно восстанавливается из кода по Y = (R + X * 2^E) / 10^D
+			for i, code := range table.Data_int {
+				if code == MissingCode {
+					if !IsMissing(table.Data[i]) {
+						t.Fatalf("точка %d с кодом пропуска имеет значение %v", i, table.Data[i])
+					}
+					continue
+				}
+				value := (float64(table.Reference) + float64(code)*math.Pow(2, float64(table.BinaryScale))) / math.Pow(10, float64(table.DecimalScale))
+				if math.Abs(value-table.Data[i]) > 1e-9 {
+					t.Fatalf("точка %d: из кода %d восстановлено %v, значение %v", i, code, value, table.Data[i])
+				}
+			}
+		})
+	}
+	StorePackedCodes = false
+	message, err := decodeBytes(t, simpleMessage(3, 1, []uint64{0, 5, 255}, 8, 250, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table := newTable(message); len(table.Data_int) != 0 {
+		t.Fatalf("при DATA_INT=none сохранены коды %v", table.Data_int)
+	}
+}
@@ -0,0 +1,135 @@
+package grib2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+var (
+	// DecodeWorkers Количество горутин, параллельно декодирующих сообщения одного файла
+	DecodeWorkers = runtime.NumCPU()
+	// SkipCorrupt Пропускать поврежденные сообщения и продолжать чтение файла вместо прекращения его обработки
+	SkipCorrupt bool
+)
+
+// decodeJob Задание на декодирование одного сообщения
+type decodeJob struct {
+	raw     *rawMessage
+	message *Message
+	err     error
+	done    chan struct{}
+}
+
+// decodeRaw Декодирует сырое сообщение, превращая панику декодера в ошибку.
+// Для сообщения, не прошедшего правила отбора rules, возвращает nil без ошибки
+func decodeRaw(raw *rawMessage, name string, rules Rules) (message *Message, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = locate(fmt.Errorf("%w: %w: %v", ErrCorruptData, ErrDecoderPanic, r), name, raw)
+		}
+	}()
+	message, err = readMsg(bytes.NewReader(raw.Body), raw.Sec0, rules)
+	if err != nil {
+		return message, locate(err, name, raw)
+	}
+	return message, nil
+}
+
+// skipCorrupt Сообщает, нужно ли пропустить сообщение с ошибкой err согласно политике SkipCorrupt
+func skipCorrupt(err error) bool {
+	var msgErr *MessageError
+	if !SkipCorrupt || !errors.As(err, &msgErr) {
+		return false
+	}
+	config.Logger.WithError(err).WithField("file", msgErr.File).WithField("message", msgErr.Message).WithField("offset", msgErr.Offset).Warn("Поврежденное сообщение пропущено")
+	return true
+}
+
+// decodeMessages Разбивает файл на сообщения и декодирует их пулом из DecodeWorkers горутин.
+// Результаты передаются в emit строго в порядке следования сообщений в файле.
+// Если emit возвращает ошибку, чтение файла прекращается
+func decodeMessages(file io.Reader, name string, emit func(*Message) error) error {
+	workers := DecodeWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan *decodeJob, workers)
+	// Очередь заданий в порядке чтения, ее размер ограничивает количество сообщений в памяти
+	order := make(chan *decodeJob, 2*workers)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer close(stop)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.message, job.err = decodeRaw(job.raw, name, IngestRules)
+				close(job.done)
+			}
+		}()
+	}
+
+	// Этап разбиения: последовательно находит границы сообщений и раздает их на декодирование
+	var splitErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(order)
+		defer close(jobs)
+		scanner := newMessageScanner(file, name)
+		for {
+			raw, err := scanner.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if skipCorrupt(err) {
+					// Поиск следующего сообщения продолжается сразу после индикатора поврежденного
+					scanner.Resync()
+					continue
+				}
+				splitErr = err
+				return
+			}
+			job := &decodeJob{raw: raw, done: make(chan struct{})}
+			select {
+			case order <- job:
+			case <-stop:
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	for job := range order {
+		<-job.done
+		if job.err != nil {
+			if skipCorrupt(job.err) {
+				continue
+			}
+			return job.err
+		}
+		// Сообщение отброшено правилами отбора
+		if job.message == nil {
+			continue
+		}
+		if err := emit(job.message); err != nil {
+			return err
+		}
+	}
+	// Канал order закрыт только после завершения этапа разбиения, поэтому splitErr уже записан
+	return splitErr
+}
@@ -0,0 +1,104 @@
+package grib2
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// multiMessageFile Возвращает файл из count сообщений с мусором между ними. Сообщение i имеет
+// время прогноза i и значения 250..254, 250+i
+func multiMessageFile(count int) []byte {
+	var file []byte
+	for i := 0; i < count; i++ {
+		file = append(file, "junk"...)
+		file = append(file, simpleMessage(3, 2, []uint64{0, 1, 2, 3, 4, uint64(i)}, 8, 250, 0, 0, uint32(i))...)
+	}
+	return file
+}
+
+// decodedField Время прогноза и значения декодированного сообщения
+type decodedField struct {
+	forecast int32
+	data     Values
+}
+
+// decodeAll Декодирует файл с DecodeWorkers = workers и возвращает сообщения в порядке emit
+func decodeAll(t *testing.T, file []byte, workers int, skip bool) ([]decodedField, error) {
+	t.Helper()
+	defer func(workers int, skip bool) { DecodeWorkers, SkipCorrupt = workers, skip }(DecodeWorkers, SkipCorrupt)
+	DecodeWorkers, SkipCorrupt = workers, skip
+	var fields []decodedField
+	err := decodeMessages(bytes.NewReader(file), "test", func(m *Message) error {
+		fields = append(fields, decodedField{m.Section4.ProductDefinitionTemplate.ForecastTime, m.Section7.Data})
+		return nil
+	})
+	return fields, err
+}
+
+func TestDecodeMessagesOrder(t *testing.T) {
+	file := multiMessageFile(50)
+	var reference []decodedField
+	for _, workers := range []int{1, 2, 8} {
+		fields, err := decodeAll(t, file, workers, false)
+		if err != nil {
+			t.Fatalf("workers %d: %v", workers, err)
+		}
+		if len(fields) != 50 {
+			t.Fatalf("workers %d: декодировано %d сообщений, ожидалось 50", workers, len(fields))
+		}
+		for i, field := range fields {
+			want := Values{250, 251, 252, 253, 254, 250 + float64(i)}
+			if field.forecast != int32(i) || !reflect.DeepEqual(field.data, want) {
+				t.Fatalf("workers %d: сообщение %d: время %d, значения %v", workers, i, field.forecast, field.data)
+			}
+		}
+		if reference == nil {
+			reference = fields
+		} else if !reflect.DeepEqual(fields, reference) {
+			t.Fatalf("workers %d: результат отличается от последовательного декодирования", workers)
+		}
+	}
+}
+
+func TestDecodeMessagesCorrupt(t *testing.T) {
+	good := func(forecast uint32) []byte {
+		return simpleMessage(3, 2, []uint64{0, 1, 2, 3, 4, 5}, 8, 250, 0, 0, forecast)
+	}
+	broken := good(1)
+	broken[len(broken)-1] = 'X'
+	file := bytes.Join([][]byte{good(0), broken, good(2), good(3)}, nil)
+
+	tests := []struct {
+		name     string
+		workers  int
+		skip     bool
+		forecast []int32
+		err      error
+	}{
+		{"остановка, 1 горутина", 1, false, []int32{0}, ErrBadEndMarker},
+		{"остановка, 4 горутины", 4, false, []int32{0}, ErrBadEndMarker},
+		{"пропуск, 1 горутина", 1, true, []int32{0, 2, 3}, nil},
+		{"пропуск, 4 горутины", 4, true, []int32{0, 2, 3}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields, err := decodeAll(t, file, test.workers, test.skip)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			var msgErr *MessageError
+			if test.err != nil && (!errors.As(err, &msgErr) || msgErr.Message != 1) {
+				t.Fatalf("ошибка %v не указывает на сообщение 1", err)
+			}
+			var forecast []int32
+			for _, field := range fields {
+				forecast = append(forecast, field.forecast)
+			}
+			if !reflect.DeepEqual(forecast, test.forecast) {
+				t.Fatalf("переданы сообщения %v, ожидались %v", forecast, test.forecast)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Parameter Описание параметра продукта (Code table 4.2)
+type Parameter struct {
+	Discipline   uint8  `json:"discipline"`
+	Category     uint8  `json:"category"`
+	Number       uint8  `json:"number"`
+	Name         string `json:"name"`                   // Полное название, например "Temperature"
+	Unit         string `json:"unit"`                   // Единицы измерения, например "K"
+	ShortName    string `json:"shortName"`              // Короткое имя в стиле wgrib2, например "TMP"
+	StandardName string `json:"standardName,omitempty"` // Стандартное имя CF, если оно есть
+	CodeTable    string `json:"codeTable,omitempty"`    // Кодовая таблица значений категориального параметра, например "4.201"
+	EcCodesName  string `json:"ecCodesName,omitempty"`  // Короткое имя в ecCodes, например "t"
+}
+
+// Categorical Сообщает, что значения параметра являются кодами из таблицы CodeTable
+func (p Parameter) Categorical() bool {
+	return p.CodeTable != ""
+}
+
+// Legend Возвращает расшифровку кодов, встречающихся в значениях категориального параметра:
+// ключ — значение в десятичной записи, значение — описание из таблицы CodeTable.
+// Для некатегориальных параметров возвращает nil
+func (p Parameter) Legend(section1 Section1, values Values) map[string]string {
+	if !p.Categorical() {
+		return nil
+	}
+	legend := map[string]string{}
+	for _, value := range values {
+		if math.IsNaN(value) || value != math.Trunc(value) {
+			continue
+		}
+		key := strconv.FormatFloat(value, 'f', -1, 64)
+		if _, ok := legend[key]; ok {
+			continue
+		}
+		description, ok := Tables.Code(section1, p.CodeTable, int(value))
+		if !ok {
+			description = fmt.Sprint("Unknown ", value)
+		}
+		legend[key] = description
+	}
+	return legend
+}
+
+// LookupParameter Возвращает описание параметра по дисциплине (Секция 0), категории и номеру (Секция 4)
+// с учетом версий таблиц из Секции 1. Для параметров без короткого имени в таблице оно составляется
+// как в wgrib2: "var<дисциплина>_<категория>_<номер>"
+func LookupParameter(section1 Section1, discipline uint8, category uint8, number uint8) Parameter {
+	parameter, ok := Tables.Parameter(section1, discipline, category, number)
+	if !ok {
+		parameter = Parameter{
+			Discipline: discipline,
+			Category:   category,
+			Number:     number,
+			Name:       fmt.Sprint("Unknown ", number),
+		}
+	}
+	if parameter.ShortName == "" {
+		parameter.ShortName = fmt.Sprintf("var%d_%d_%d", discipline, category, number)
+	}
+	return parameter
+}
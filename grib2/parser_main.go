@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -31,11 +32,20 @@ var (
 	SaveAs   string
 	Conns    int
 	CountCpu int
+	// StrictValidation Проверять структуру каждого файла перед загрузкой
+	StrictValidation bool
 )
 
 func Grib_menu(dirPath []fs.DirEntry, cfg *config.Config) error {
 	bufChannel := make(chan *Table, 10)
 	msgChannel := make(chan *Message, 20)
+	file, err := strconv.Atoi(cfg.CountFilePerTick)
+	if err != nil {
+		return err
+	}
+	if file <= 0 {
+		return errors.New("Некорректно указана переменая COUNT_FILE_PER_TICK!")
+	}
 	CountCpu = runtime.NumCPU() - 1
 	Conns = int(CountCpu/3)
 	if Conns > 5 {
@@ -117,13 +127,6 @@ func Grib_menu(dirPath []fs.DirEntry, cfg *config.Config) error {
 	// 	close(msgChannel)
 	// 	return err
 	// }
-	file, err:=strconv.Atoi(cfg.CountFilePerTick)
-	if err!=nil{
-		return err
-	}
-	if file<=0{
-		return errors.New("Некорректно указана переменая COUNT_FILE_PER_TICK!")
-	}
 	for i := 0; i < file; i++ {
 		eg.Go(func() error {
 			config.Logger.Info("Парсер стартовал!")
@@ -136,6 +139,10 @@ func Grib_menu(dirPath []fs.DirEntry, cfg *config.Config) error {
 		close(msgChannel)
 		return err
 	}
+	// Поля, задержанные этапами обработки до чтения всех файлов
+	for _, table := range Stages.Flush() {
+		bufChannel <- table
+	}
 	close(bufChannel)
 	close(msgChannel)
 	if err := egg.Wait(); err != nil {
@@ -145,6 +152,167 @@ func Grib_menu(dirPath []fs.DirEntry, cfg *config.Config) error {
 	return nil
 }
 
+// Configure Разбирает переменные окружения декодера и этапов обработки, устанавливает
+// соответствующие настройки пакета и собирает этапы Stages. Вызывается до запуска Grib_menu,
+// чтобы ошибка в настройках обнаруживалась до начала чтения и сохранения
+func Configure(cfg *config.Config) error {
+	if cfg.DecodeWorkers != "" {
+		workers, err := strconv.Atoi(cfg.DecodeWorkers)
+		if err != nil {
+			return err
+		}
+		if workers <= 0 {
+			return errors.New("Некорректно указана переменая DECODE_WORKERS!")
+		}
+		DecodeWorkers = workers
+	}
+	switch cfg.OnCorrupt {
+	case "fail", "":
+		SkipCorrupt = false
+	case "skip":
+		SkipCorrupt = true
+	default:
+		return errors.New("Некорректно указана переменая ON_CORRUPT_MESSAGE!")
+	}
+	switch cfg.Validate {
+	case "strict":
+		StrictValidation = true
+	case "":
+		StrictValidation = false
+	default:
+		return errors.New("Некорректно указана переменая VALIDATE!")
+	}
+	for _, limit := range []struct {
+		value string
+		name  string
+		dst   *uint64
+	}{
+		{cfg.MaxMessageSize, "MAX_MESSAGE_SIZE", &DecodeLimits.MaxMessageSize},
+		{cfg.MaxPoints, "MAX_POINTS", &DecodeLimits.MaxPoints},
+		{cfg.MaxGroups, "MAX_GROUPS", &DecodeLimits.MaxGroups},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(limit.value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая %s: %w", limit.name, err)
+		}
+		*limit.dst = value
+	}
+	switch cfg.MissingValue {
+	case "", "nan", "NaN":
+		MissingValue = math.NaN()
+		UseMissingSubstitutes = false
+	case "substitute":
+		MissingValue = math.NaN()
+		UseMissingSubstitutes = true
+	default:
+		value, err := strconv.ParseFloat(cfg.MissingValue, 64)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая MISSING_VALUE: %w", err)
+		}
+		MissingValue = value
+		UseMissingSubstitutes = false
+	}
+	round, err := strconv.ParseBool(cfg.RoundValues)
+	if err != nil {
+		return fmt.Errorf("Некорректно указана переменая ROUND_VALUES: %w", err)
+	}
+	RoundValues = round
+	switch cfg.ValueType {
+	case "float64", "":
+		Float32Values = false
+	case "float32":
+		Float32Values = true
+	default:
+		return errors.New("Некорректно указана переменая VALUE_TYPE!")
+	}
+	switch cfg.DataInt {
+	case "packed", "":
+		StorePackedCodes = true
+	case "none":
+		StorePackedCodes = false
+	default:
+		return errors.New("Некорректно указана переменая DATA_INT!")
+	}
+	if cfg.TablesDir != "" {
+		if err := LoadTables(cfg.TablesDir); err != nil {
+			return fmt.Errorf("Ошибка загрузки кодовых таблиц из TABLES_DIR: %w", err)
+		}
+	}
+	Percentiles, err = ParsePercentiles(cfg.Percentiles)
+	if err != nil {
+		return fmt.Errorf("Некорректно указана переменая STAT_PERCENTILES: %w", err)
+	}
+	IngestRules, err = ParseRules(cfg.Include, cfg.Exclude)
+	if err != nil {
+		return fmt.Errorf("Некорректно указаны переменые INCLUDE/EXCLUDE: %w", err)
+	}
+	Stages = nil
+	if cfg.Region != "" {
+		region, err := ParseRegion(cfg.Region)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая REGION: %w", err)
+		}
+		Stages = append(Stages, &RegionStage{Region: region})
+	}
+	rotate, err := strconv.ParseBool(cfg.RotateWinds)
+	if err != nil {
+		return fmt.Errorf("Некорректно указана переменая ROTATE_WINDS: %w", err)
+	}
+	if rotate {
+		Stages = append(Stages, &RotateStage{})
+	}
+	if cfg.Derive != "" {
+		derive, err := ParseDerive(cfg.Derive)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая DERIVE: %w", err)
+		}
+		Stages = append(Stages, derive)
+	}
+	if cfg.Units != "" {
+		units, err := ParseUnits(cfg.Units)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая UNITS: %w", err)
+		}
+		Stages = append(Stages, units)
+	}
+	if cfg.ProfileLevels != "" || cfg.ProfilePoints != "" {
+		profiles, err := ParseProfiles(cfg.Profiles, cfg.ProfileLevels, cfg.ProfilePoints, cfg.ProfileDir)
+		if err != nil {
+			return fmt.Errorf("Некорректно указаны переменые PROFILES/PROFILE_LEVELS/PROFILE_POINTS/PROFILE_DIR: %w", err)
+		}
+		Stages = append(Stages, profiles)
+	}
+	if cfg.TimeStep != "" {
+		interpolation, err := ParseTimeInterpolation(cfg.TimeStep, cfg.TimeParameters)
+		if err != nil {
+			return fmt.Errorf("Некорректно указаны переменые TIME_STEP/TIME_PARAMETERS: %w", err)
+		}
+		Stages = append(Stages, interpolation)
+	}
+	if cfg.Regrid != "" {
+		target, err := ParseGrid0(cfg.Regrid)
+		if err != nil {
+			return fmt.Errorf("Некорректно указана переменая REGRID: %w", err)
+		}
+		method, err := ParseInterpolation(cfg.RegridMethod)
+		if err != nil || method == InverseDistance {
+			return errors.New("Некорректно указана переменая REGRID_METHOD!")
+		}
+		Stages = append(Stages, &RegridStage{Target: target, Method: method})
+	}
+	if cfg.EnsembleStats != "" {
+		ensemble, err := ParseEnsembleStats(cfg.EnsembleStats, cfg.Thresholds)
+		if err != nil {
+			return fmt.Errorf("Некорректно указаны переменые ENSEMBLE_STATS/ENSEMBLE_THRESHOLDS: %w", err)
+		}
+		Stages = append(Stages, ensemble)
+	}
+	return nil
+}
+
 func Parse(dirPath []fs.DirEntry, cfg *config.Config, bufChannel chan *Table, msg chan *Message) error {
 	// Проверяет каждый элемент в папке и, если это файл отправляет его на чтение
 	SaveAs = cfg.SaveAs
@@ -155,6 +323,12 @@ func Parse(dirPath []fs.DirEntry, cfg *config.Config, bufChannel chan *Table, ms
 				config.Logger.Warn("Пустой путь к файлу!")
 				continue
 			}
+			if StrictValidation {
+				ok := checkValid(filePath)
+				if !ok {
+					continue
+				}
+			}
 			if cfg.SaveAs == "database" {
 				ok := checkExist(filePath)
 				if !ok {
@@ -174,7 +348,7 @@ func Parse(dirPath []fs.DirEntry, cfg *config.Config, bufChannel chan *Table, ms
 			}
 			defer gribFile.Close()
 			config.Logger.WithField("file",filePath).Info("Парсер стартовал...")
-			if err:=readMessages(gribFile,bufChannel,msg); err!=nil{
+			if err:=readMessages(gribFile,filePath,bufChannel,msg); err!=nil{
 				return err
 			}
 			// errGroup.Go(func() error {
@@ -198,6 +372,28 @@ func Parse(dirPath []fs.DirEntry, cfg *config.Config, bufChannel chan *Table, ms
 
 	return nil
 }
+// checkValid Проверяет структуру файла перед загрузкой, файлы с нарушениями уровня error отклоняются
+func checkValid(filePath string) bool {
+	gribFile, err := os.Open(filePath)
+	if err != nil {
+		config.Logger.WithField("file", filePath).WithError(err).Warn("Ошибка при открытии файла")
+		return false
+	}
+	defer gribFile.Close()
+	report, err := Validate(gribFile, filePath)
+	if err != nil {
+		config.Logger.WithField("file", filePath).WithError(err).Warn("Ошибка проверки файла")
+		return false
+	}
+	for _, v := range report.Violations {
+		config.Logger.WithField("file", filePath).Warn(v.String())
+	}
+	if report.HasErrors() {
+		config.Logger.WithField("file", filePath).Error("Файл отклонен строгой проверкой структуры!")
+		return false
+	}
+	return true
+}
 func checkExistCh(filePath string, cfg *config.Config) bool {
 	gribFile, err := os.Open(filePath)
 	if err != nil {
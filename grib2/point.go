@@ -0,0 +1,218 @@
+package grib2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrOutsideGrid Точка лежит вне области сетки
+var ErrOutsideGrid = errors.New("точка вне области сетки")
+
+// Interpolation Способ получения значения в точке между узлами сетки
+type Interpolation uint8
+
+const (
+	// Nearest Значение ближайшего узла
+	Nearest Interpolation = iota
+	// Bilinear Билинейная интерполяция по четырем окружающим узлам в индексах сетки
+	Bilinear
+	// InverseDistance Среднее четырех окружающих узлов с весами, обратными квадрату расстояния до них
+	InverseDistance
+	// Conservative Среднее по площади ячейки целевой сетки, только для перевода полей на другую сетку
+	Conservative
+)
+
+// interpolationNames Названия способов интерполяции
+var interpolationNames = map[Interpolation]string{
+	Nearest:         "nearest",
+	Bilinear:        "bilinear",
+	InverseDistance: "idw",
+	Conservative:    "conservative",
+}
+
+// ParseInterpolation Возвращает способ интерполяции по названию: nearest, bilinear, idw, conservative
+func ParseInterpolation(name string) (Interpolation, error) {
+	for method, methodName := range interpolationNames {
+		if strings.EqualFold(name, methodName) {
+			return method, nil
+		}
+	}
+	return 0, fmt.Errorf("неизвестный способ интерполяции %q", name)
+}
+
+func (m Interpolation) String() string {
+	if name, ok := interpolationNames[m]; ok {
+		return name
+	}
+	return itoa(uint8(m))
+}
+
+// weight Вклад узла сетки в значение в точке
+type weight struct {
+	Offset int
+	Weight float64
+}
+
+// indexEpsilon Допуск на погрешность вычисления индексов точек на границе сетки
+const indexEpsilon = 1e-6
+
+// GridIndex Возвращает дробные индексы точки lat, lon на сетке: i вдоль строки, j — номер строки.
+// Для точек вне области сетки возвращает ErrOutsideGrid
+func GridIndex(section3 Section3, lat, lon float64) (float64, float64, error) {
+	projection, err := NewProjection(section3.Definition)
+	if err != nil {
+		return 0, 0, err
+	}
+	return gridLocate(projection, lat, lon)
+}
+
+// gridLocate Возвращает индексы точки внутри сетки: на замкнутой по долготе сетке i приводится к [0, ni)
+func gridLocate(projection Projection, lat, lon float64) (float64, float64, error) {
+	if lat < -90 || lat > 90 || math.IsNaN(lat) || math.IsNaN(lon) {
+		return 0, 0, fmt.Errorf("%w: некорректные координаты %g, %g", ErrOutsideGrid, lat, lon)
+	}
+	i, j, ok := projection.Index(lat, lon)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: точка %g, %g не проецируется на сетку", ErrOutsideGrid, lat, lon)
+	}
+	ni, nj := projection.Size()
+	if projection.Periodic() {
+		i = math.Mod(math.Mod(i, float64(ni))+float64(ni), float64(ni))
+	} else if i < -indexEpsilon || i > float64(ni-1)+indexEpsilon {
+		return 0, 0, fmt.Errorf("%w: точка %g, %g", ErrOutsideGrid, lat, lon)
+	}
+	if j < -indexEpsilon || j > float64(nj-1)+indexEpsilon {
+		return 0, 0, fmt.Errorf("%w: точка %g, %g", ErrOutsideGrid, lat, lon)
+	}
+	if !projection.Periodic() {
+		i = math.Max(0, math.Min(i, float64(ni-1)))
+	}
+	return i, math.Max(0, math.Min(j, float64(nj-1))), nil
+}
+
+// pointWeights Возвращает узлы сетки и их веса для значения в точке
+func pointWeights(projection Projection, lat, lon float64, method Interpolation) ([]weight, error) {
+	i, j, err := gridLocate(projection, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	ni, _ := projection.Size()
+	if method == Nearest {
+		ri, rj := int(math.Round(i)), int(math.Round(j))
+		if ri == ni {
+			// На замкнутой сетке точка между последним и первым узлами ближе к первому
+			ri = 0
+		}
+		return []weight{{Offset: projection.Offset(ri, rj), Weight: 1}}, nil
+	}
+	i0, j0, i1, j1 := cell(projection, i, j)
+	fi, fj := i-float64(i0), j-float64(j0)
+	switch method {
+	case Bilinear:
+		corners := []weight{
+			{projection.Offset(i0, j0), (1 - fi) * (1 - fj)},
+			{projection.Offset(i1, j0), fi * (1 - fj)},
+			{projection.Offset(i0, j1), (1 - fi) * fj},
+			{projection.Offset(i1, j1), fi * fj},
+		}
+		weights := corners[:0]
+		for _, corner := range corners {
+			if corner.Weight > 0 {
+				weights = append(weights, corner)
+			}
+		}
+		return weights, nil
+	case InverseDistance:
+		var weights []weight
+		for _, node := range [][2]int{{i0, j0}, {i1, j0}, {i0, j1}, {i1, j1}} {
+			nodeLat, nodeLon := projection.LatLon(float64(node[0]), float64(node[1]))
+			distance := angularDistance(lat, lon, nodeLat, nodeLon)
+			if distance < 1e-12 {
+				return []weight{{Offset: projection.Offset(node[0], node[1]), Weight: 1}}, nil
+			}
+			weights = append(weights, weight{Offset: projection.Offset(node[0], node[1]), Weight: 1 / (distance * distance)})
+		}
+		return weights, nil
+	case Conservative:
+		return nil, errors.New("сохраняющая интерполяция применяется только при переводе поля на другую сетку")
+	}
+	return nil, fmt.Errorf("неизвестный способ интерполяции %s", method)
+}
+
+// cell Возвращает индексы узлов ячейки, содержащей точку. На замкнутой сетке за последним
+// узлом строки следует первый, на краю обычной сетки ячейка вырождается
+func cell(projection Projection, i, j float64) (int, int, int, int) {
+	ni, nj := projection.Size()
+	i0, j0 := int(math.Floor(i)), int(math.Floor(j))
+	i0, j0 = min(i0, ni-1), min(j0, nj-1)
+	i1, j1 := i0+1, min(j0+1, nj-1)
+	if i1 == ni {
+		i1 = i0
+		if projection.Periodic() {
+			i1 = 0
+		}
+	}
+	return i0, j0, i1, j1
+}
+
+// angularDistance Возвращает угловое расстояние между точками в радианах
+func angularDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := radians(lat1), radians(lat2)
+	dphi, dlambda := phi2-phi1, radians(lon2-lon1)
+	a := math.Sin(dphi/2)*math.Sin(dphi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dlambda/2)*math.Sin(dlambda/2)
+	return 2 * math.Asin(math.Sqrt(math.Min(1, a)))
+}
+
+// apply Вычисляет значение по весам узлов. Узлы без значений не учитываются, остальные веса
+// нормируются. Если значений нет ни в одном узле, возвращается MissingValue
+func apply(weights []weight, data Values) float64 {
+	sum, total := 0.0, 0.0
+	for _, w := range weights {
+		value := data[w.Offset]
+		if IsMissing(value) {
+			continue
+		}
+		sum += w.Weight * value
+		total += w.Weight
+	}
+	if total == 0 {
+		return MissingValue
+	}
+	return sum / total
+}
+
+// Interpolate Возвращает значение поля data на сетке section3 в точке lat, lon (градусы, долгота
+// в любом диапазоне). Для точек вне области сетки возвращает ErrOutsideGrid, если значений
+// в окружающих узлах нет — MissingValue
+func Interpolate(section3 Section3, data Values, lat, lon float64, method Interpolation) (float64, error) {
+	projection, err := NewProjection(section3.Definition)
+	if err != nil {
+		return 0, err
+	}
+	ni, nj := projection.Size()
+	if len(data) != ni*nj {
+		return 0, fmt.Errorf("количество значений %d не совпадает с размером сетки %dx%d", len(data), ni, nj)
+	}
+	weights, err := pointWeights(projection, lat, lon, method)
+	if err != nil {
+		return 0, err
+	}
+	return apply(weights, data), nil
+}
+
+// Index Возвращает дробные индексы точки на сетке поля
+func (t *Table) Index(lat, lon float64) (float64, float64, error) {
+	return GridIndex(t.Section3.Sec3, lat, lon)
+}
+
+// At Возвращает значение поля в точке, например t.At(55.75, 37.62, Bilinear)
+func (t *Table) At(lat, lon float64, method Interpolation) (float64, error) {
+	return Interpolate(t.Section3.Sec3, t.Data, lat, lon, method)
+}
+
+// At Возвращает значение поля сообщения в точке
+func (m *Message) At(lat, lon float64, method Interpolation) (float64, error) {
+	return Interpolate(m.Section3, m.Section7.Data, lat, lon, method)
+}
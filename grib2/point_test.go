@@ -0,0 +1,180 @@
+package grib2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	// Сетка 3x2: строка 60° с. ш. — 0, 10, 20; строка 59° с. ш. — 30, 40, 50
+	message, err := decodeBytes(t, simpleMessage(3, 2, []uint64{0, 10, 20, 30, 40, 50}, 8, 0, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gap := Values{0, 10, 20, MissingValue, 40, 50}
+	tests := []struct {
+		name     string
+		data     Values
+		lat, lon float64
+		method   Interpolation
+		want     float64
+		err      error
+	}{
+		{"ближайший узел", nil, 59.6, 1.4, Nearest, 10, nil},
+		{"ближайший узел, долгота больше 360", nil, 59.6, 361.4, Nearest, 10, nil},
+		{"билинейная, центр ячейки", nil, 59.5, 0.5, Bilinear, 20, nil},
+		{"билинейная, на строке", nil, 60, 1.25, Bilinear, 12.5, nil},
+		{"билинейная, на краю сетки", nil, 59.75, 2, Bilinear, 27.5, nil},
+		{"билинейная, узел без значения", gap, 59.5, 0.5, Bilinear, 50.0 / 3, nil},
+		{"обратные расстояния, в узле", nil, 59, 1, InverseDistance, 40, nil},
+		{"обратные расстояния", nil, 59.5, 0, InverseDistance, 18.26287303174942, nil},
+		{"без значений в узлах", Values{MissingValue, 10, 20, 30, 40, 50}, 60, 0, Nearest, MissingValue, nil},
+		{"севернее сетки", nil, 61, 0, Bilinear, 0, ErrOutsideGrid},
+		{"восточнее сетки", nil, 60, 2.5, Nearest, 0, ErrOutsideGrid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := test.data
+			if data == nil {
+				data = message.Section7.Data
+			}
+			got, err := Interpolate(message.Section3, data, test.lat, test.lon, test.method)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if IsMissing(test.want) {
+				if !IsMissing(got) {
+					t.Fatalf("значение %g, ожидался пропуск", got)
+				}
+				return
+			}
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Fatalf("значение %.12g, ожидалось %.12g", got, test.want)
+			}
+		})
+	}
+}
+
+// checkRoundTrip Проверяет, что индексы углов и середины сетки восстанавливаются по их координатам
+func checkRoundTrip(t *testing.T, projection Projection) {
+	t.Helper()
+	ni, nj := projection.Size()
+	for _, node := range [][2]float64{{0, 0}, {float64(ni - 1), 0}, {0, float64(nj - 1)}, {float64(ni - 1), float64(nj - 1)}, {float64(ni) / 2, float64(nj) / 3}} {
+		lat, lon := projection.LatLon(node[0], node[1])
+		i, j, ok := projection.Index(lat, lon)
+		if !ok || math.Abs(i-node[0]) > 1e-6 || math.Abs(j-node[1]) > 1e-6 {
+			t.Fatalf("узел %v: координаты %g, %g, индексы %g, %g (%t)", node, lat, lon, i, j, ok)
+		}
+	}
+}
+
+func TestProjections(t *testing.T) {
+	sphere := GridHeader{EarthShape: 6}
+	// Шаг южной стереографической сетки 3x3 с полюсом в центре и углами на 80° ю. ш. при истинной широте 60° ю. ш.
+	southStep := int32(math.Round(6371229 * (1 + math.Sin(radians(60))) * math.Tan(radians(5)) / math.Sqrt2 * 1000))
+	tests := []struct {
+		name       string
+		definition interface{}
+		// Координаты узлов с индексами i, j: широта, долгота, i, j
+		nodes     [][4]float64
+		tolerance float64
+	}{
+		{
+			"Меркатор, сетка NCEP 204",
+			&Grid10{GridHeader: sphere, Ni: 93, Nj: 68, La1: -25000000, Lo1: 110000000, Lad: 20000000, La2: 60644000, Lo2: 250871000, ScanningMode: 0x40, Di: 160000000, Dj: 160000000},
+			[][4]float64{{-25, 110, 0, 0}, {60.644, -109.129, 92, 67}},
+			1e-3,
+		},
+		{
+			"Меркатор с востока на запад",
+			&Grid10{GridHeader: sphere, Ni: 93, Nj: 68, La1: -25000000, Lo1: 250871000, Lad: 20000000, La2: 60644000, Lo2: 110000000, ScanningMode: 0xc0, Di: 160000000, Dj: 160000000},
+			[][4]float64{{-25, -109.129, 0, 0}, {-25, 110, 92, 0}, {60.644, 110, 92, 67}},
+			1e-3,
+		},
+		{
+			"северная стереографическая, сетка NCEP 242",
+			&Grid20{GridHeader: sphere, Nx: 553, Ny: 425, La1: 30000000, Lo1: 187000000, Lad: 60000000, Lov: 225000000, Dx: 11250000, Dy: 11250000, ScanningMode: 0x40},
+			[][4]float64{{30, -173, 0, 0}, {70.111, -62.850, 552, 424}},
+			1e-3,
+		},
+		{
+			"южная стереографическая",
+			&Grid20{GridHeader: sphere, Nx: 3, Ny: 3, La1: -80000000, Lo1: -135000000, Lad: -60000000, Dx: southStep, Dy: southStep, ProjectionCenter: 0x80, ScanningMode: 0x40},
+			[][4]float64{{-80, -135, 0, 0}, {-90, 0, 1, 1}, {-80, 45, 2, 2}, {-80, -45, 0, 2}, {-80, 135, 2, 0}, {-80, 0, 1, 1 + math.Sqrt2}},
+			1e-6,
+		},
+		{
+			"Ламберт, сетка NCEP 218",
+			&Grid30{GridHeader: sphere, Nx: 614, Ny: 428, La1: 12190000, Lo1: 226541000, Lad: 25000000, Lov: 265000000, Dx: 12191000, Dy: 12191000, ScanningMode: 0x40, Latin1: 25000000, Latin2: 25000000},
+			[][4]float64{{12.19, -133.459, 0, 0}, {57.328, -49.420, 613, 427}},
+			5e-3,
+		},
+		{
+			"Гауссова сетка F640",
+			&Grid40{GridHeader: sphere, Ni: 2560, Nj: 1280, La1: 89892396, Lo1: 0, La2: -89892396, Lo2: 359859375, Di: 140625, N: 640},
+			[][4]float64{{89.892396445590066, 0, 0, 0}, {89.753004943174, 0, 0, 1}, {-89.892396445590066, 180, 1280, 1279}},
+			1e-9,
+		},
+		{
+			"Гауссова сетка F80 с юга на север",
+			&Grid40{GridHeader: sphere, Ni: 320, Nj: 160, La1: -89141519, Lo1: 0, La2: 89141519, Lo2: 358875000, Di: 1125000, N: 80, ScanningMode: 0x40},
+			[][4]float64{{-89.14151942646, 0, 0, 0}, {89.14151942646, -1.125, 319, 159}},
+			1e-9,
+		},
+		{
+			"вид из космоса",
+			&Grid90{GridHeader: sphere, Nx: 3712, Ny: 3712, Dx: 3622, Dy: 3622, Xp: 1856000, Yp: 1856000, Nr: 6610689},
+			[][4]float64{{0, 0, 1856, 1856}, {0, 30, 1856 + spaceViewAngle(30, 0)*3622/spaceViewDiameter(), 1856}, {30, 0, 1856, 1856 - spaceViewAngle(0, 30)*3622/spaceViewDiameter()}},
+			1e-6,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projection, err := newProjection(test.definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, node := range test.nodes {
+				lat, lon := projection.LatLon(node[2], node[3])
+				// Долгота полюса не определена
+				pole := math.Abs(node[0]) == 90
+				if math.Abs(lat-node[0]) > test.tolerance || !pole && math.Abs(normalizeLongitude(lon-node[1])) > test.tolerance {
+					t.Fatalf("узел %g, %g: координаты %.9g, %.9g, ожидались %g, %g", node[2], node[3], lat, lon, node[0], node[1])
+				}
+			}
+			checkRoundTrip(t, projection)
+		})
+	}
+}
+
+// spaceViewDiameter Возвращает видимый со спутника на расстоянии 6.610689 радиусов угловой диаметр Земли
+func spaceViewDiameter() float64 {
+	return 2 * math.Asin(1/6.610689)
+}
+
+// spaceViewAngle Возвращает угол сканирования точки на экваторе с долготой lon или на нулевом
+// меридиане с широтой lat для спутника над 0° в. д. на расстоянии 6.610689 радиусов
+func spaceViewAngle(lon, lat float64) float64 {
+	if lat == 0 {
+		return math.Atan(math.Sin(radians(lon)) / (6.610689 - math.Cos(radians(lon))))
+	}
+	r1 := 6.610689 - math.Cos(radians(lat))
+	return math.Asin(math.Sin(radians(lat)) / math.Hypot(r1, math.Sin(radians(lat))))
+}
+
+func TestSpaceViewHorizon(t *testing.T) {
+	projection, err := newProjection(&Grid90{GridHeader: GridHeader{EarthShape: 6}, Nx: 3712, Ny: 3712, Dx: 3622, Dy: 3622, Xp: 1856000, Yp: 1856000, Nr: 6610689})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := projection.Index(0, 100); ok {
+		t.Fatal("точка за горизонтом спроецирована")
+	}
+	if lat, lon := projection.LatLon(0, 0); !math.IsNaN(lat) || !math.IsNaN(lon) {
+		t.Fatalf("угол кадра вне диска Земли: %g, %g", lat, lon)
+	}
+}
@@ -0,0 +1,79 @@
+package grib2
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// RoundValues Округлять декодированные значения до точности, которую дает упаковка поля
+	RoundValues bool
+	// Float32Values Хранить и записывать значения с одинарной точностью
+	Float32Values bool
+)
+
+// precision Возвращает количество десятичных знаков после запятой, при котором упакованные значения
+// (R + k·2^E)·10^-D записываются точно: у шага 2^E·10^-D при E < 0 их -E + D, иначе D, у опорного
+// значения — столько, сколько в кратчайшей записи R, плюс D. Может быть отрицательным при шаге
+// больше 10 и целом опорном значении
+func (template Data0) precision() int {
+	decimalScale := int(template.DecimalScale)
+	if template.DecimalScale == MissingInt16 {
+		decimalScale = 0
+	}
+	binaryScale := int(template.BinaryScale)
+	if template.BinaryScale == MissingInt16 {
+		binaryScale = 0
+	}
+	reference := strconv.FormatFloat(float64(template.Reference), 'f', -1, 32)
+	digits := 0
+	if dot := strings.IndexByte(reference, '.'); dot >= 0 {
+		digits = len(reference) - dot - 1
+	}
+	digits += decimalScale
+	if template.Bits == 0 {
+		// У постоянного поля шага нет, точность задается опорным значением
+		return digits
+	}
+	step := decimalScale
+	if binaryScale < 0 {
+		step -= binaryScale
+	}
+	return max(step, digits)
+}
+
+// roundFunc Возвращает функцию приведения значений поля к точности RoundValues и Float32Values
+func (template Data0) roundFunc() func(float64) float64 {
+	if !RoundValues && !Float32Values {
+		return func(value float64) float64 { return value }
+	}
+	factor := math.Pow(10, float64(template.precision()))
+	return func(value float64) float64 {
+		// Значение, знаков которого больше, чем различает float64, округлять не нужно
+		if scaled := value * factor; RoundValues && factor != 0 && math.Abs(scaled) < 1<<53 {
+			value = math.Round(scaled) / factor
+		}
+		if Float32Values {
+			value = float64(float32(value))
+		}
+		return value
+	}
+}
+
+// Float32 Возвращает значения с одинарной точностью для записи в колонки Float32
+func (v Values) Float32() []float32 {
+	values := make([]float32, len(v))
+	for i, value := range v {
+		values[i] = float32(value)
+	}
+	return values
+}
+
+// Column Возвращает значения в виде, подходящем для записи в колонку базы данных
+func (v Values) Column() interface{} {
+	if Float32Values {
+		return v.Float32()
+	}
+	return []float64(v)
+}
@@ -0,0 +1,95 @@
+package grib2
+
+import (
+	"testing"
+)
+
+func TestPrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Data0
+		digits   int
+	}{
+		{"D = 2", Data0{Reference: 27314, DecimalScale: 2, Bits: 8}, 2},
+		{"E = -4", Data0{Reference: 273.15, BinaryScale: -4, Bits: 12}, 4},
+		{"E = -4, D = 1", Data0{Reference: 2731.5, BinaryScale: -4, DecimalScale: 1, Bits: 12}, 5},
+		{"без масштаба", Data0{Reference: 100, Bits: 8}, 0},
+		{"E = 3", Data0{Reference: 100, BinaryScale: 3, Bits: 8}, 0},
+		{"D = -2", Data0{Reference: 1, DecimalScale: -2, Bits: 8}, -2},
+		{"D = -1, дробное опорное значение", Data0{Reference: 4783.27, DecimalScale: -1, Bits: 8}, 1},
+		{"постоянное поле", Data0{Reference: 273.15}, 2},
+		{"постоянное поле, D = 1", Data0{Reference: 2731.5, DecimalScale: 1}, 2},
+	}
+	for _, test := range tests {
+		if digits := test.template.precision(); digits != test.digits {
+			t.Errorf("%s: %d знаков, ожидалось %d", test.name, digits, test.digits)
+		}
+	}
+}
+
+func TestRoundValues(t *testing.T) {
+	defer func(round, float32Values bool) { RoundValues, Float32Values = round, float32Values }(RoundValues, Float32Values)
+	template := Data0{Reference: 27314, DecimalScale: 2, Bits: 8}
+	tests := []struct {
+		round, float32Values bool
+		want                 float64
+	}{
+		{false, false, 273.14 + 0.01},
+		{true, false, 273.15},
+		{false, true, float64(float32(273.14 + 0.01))},
+		{true, true, float64(float32(273.15))},
+	}
+	for _, test := range tests {
+		RoundValues, Float32Values = test.round, test.float32Values
+		if got := template.scaleFunc()(1); got != test.want {
+			t.Errorf("RoundValues=%v Float32Values=%v: %v, ожидалось %v", test.round, test.float32Values, got, test.want)
+		}
+	}
+}
+
+func TestRoundReference(t *testing.T) {
+	defer func(round bool) { RoundValues = round }(RoundValues)
+	RoundValues = true
+	tests := []struct {
+		name     string
+		template Data0
+		code     int64
+		want     float64
+	}{
+		// Шаг 10 при опорном значении 47832.7: округление до десятков сдвинуло бы значения на 2.7
+		{"D = -1, дробное опорное значение", Data0{Reference: 4783.27, DecimalScale: -1, Bits: 8}, 1, 47842.7},
+		// Шаг 0.0625: округление до сотых увело бы значения с сетки упаковки
+		{"E = -4", Data0{Reference: 273.15, BinaryScale: -4, Bits: 12}, 1, 273.2125},
+		{"E = -4, D = 1", Data0{Reference: 2731.5, BinaryScale: -4, DecimalScale: 1, Bits: 12}, 3, 273.16875},
+		// 40 знаков float64 не различает, значение остается без изменений
+		{"E = -40", Data0{Reference: 273.15, BinaryScale: -40, Bits: 12}, 0, float64(float32(273.15))},
+	}
+	for _, test := range tests {
+		if got := test.template.scaleFunc()(test.code); got != test.want {
+			t.Errorf("%s: %v, ожидалось %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFloat32Output(t *testing.T) {
+	defer func(float32Values bool) { Float32Values = float32Values }(Float32Values)
+	values := Values{float64(float32(273.15)), MissingValue}
+	tests := []struct {
+		float32Values bool
+		json          string
+	}{
+		{false, "[273.1499938964844,null]"},
+		{true, "[273.15,null]"},
+	}
+	for _, test := range tests {
+		Float32Values = test.float32Values
+		data, err := values.MarshalJSON()
+		if err != nil || string(data) != test.json {
+			t.Errorf("Float32Values=%v: %s %v, ожидалось %s", test.float32Values, data, err, test.json)
+		}
+		_, isFloat32 := values.Column().([]float32)
+		if isFloat32 != test.float32Values {
+			t.Errorf("Float32Values=%v: колонка %T", test.float32Values, values.Column())
+		}
+	}
+}
@@ -11,11 +11,18 @@ type Product0 struct {
 	Hours             uint16  `json:"hours"`
 	Minutes           uint8   `json:"minutes"`
 	TimeUnitIndicator uint8   `json:"timeUnitIndicator"`
-	ForecastTime      uint32  `json:"forecastTime"`
+	ForecastTime      int32   `json:"forecastTime"`
 	FirstSurface      Surface `json:"firstSurface"`
 	SecondSurface     Surface `json:"secondSurface"`
 }
 
+// fixSigned Переводит время прогноза и поверхности из прямого кода
+func (p *Product0) fixSigned() {
+	p.ForecastTime = signed32(p.ForecastTime)
+	p.FirstSurface.fixSigned()
+	p.SecondSurface.fixSigned()
+}
+
 //Product1 http://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_temp4-1.shtml
 type Product1 struct {
 	Product0
@@ -24,6 +31,13 @@ type Product1 struct {
 	ForecastInEnsembleCount uint8 `json:"forecastInEnsembleCount"`
 }
 
+// Ensemble Сведения об участнике ансамбля из шаблонов 4.1 и 4.11
+type Ensemble struct {
+	Type   uint8 `json:"type"`   // Тип ансамблевого прогноза (Code table 4.6)
+	Number uint8 `json:"number"` // Номер возмущения, 0 для контрольного прогноза
+	Count  uint8 `json:"count"`  // Количество прогнозов в ансамбле
+}
+
 //Product2 http://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_temp4-2.shtml
 type Product2 struct {
 	Product0
@@ -34,13 +48,13 @@ type Product2 struct {
 //Product5 http://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_temp4-5.shtml
 type Product5 struct {
 	Product0
-	ForecastProbabilityNumber  uint8  `json:"forecastProbabilityNumber"`
-	TotalForecastProbabilities uint8  `json:"totalForecastProbabilities"`
-	ProbabilityType            uint8  `json:"probabilityType"`
-	ScaleFactorLowerLimit      uint8  `json:"scaleFactorLowerLimit"`
-	ScaleValueLowerLimit       uint32 `json:"scaleValueLowerLimit"`
-	ScaleFactorUpperLimit      uint8  `json:"scaleFactorUpperLimit"`
-	ScaleValueUpperLimit       uint32 `json:"scaleValueUpperLimit"`
+	ForecastProbabilityNumber  uint8 `json:"forecastProbabilityNumber"`
+	TotalForecastProbabilities uint8 `json:"totalForecastProbabilities"`
+	ProbabilityType            uint8 `json:"probabilityType"`
+	ScaleFactorLowerLimit      int8  `json:"scaleFactorLowerLimit"`
+	ScaleValueLowerLimit       int32 `json:"scaleValueLowerLimit"`
+	ScaleFactorUpperLimit      int8  `json:"scaleFactorUpperLimit"`
+	ScaleValueUpperLimit       int32 `json:"scaleValueUpperLimit"`
 }
 
 //Product6 http://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_temp4-6.shtml
@@ -77,7 +91,13 @@ type TimeRangeSpecification struct {
 
 //Surface describes a surface for a product, see http://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_table4-5.shtml
 type Surface struct {
-	Type  uint8  `json:"type"` // type 220: Planetary Boundary Layer
-	Scale uint8  `json:"scale"`
-	Value uint32 `json:"value"` // e.g. meters above sea-level
+	Type  uint8 `json:"type"` // type 220: Planetary Boundary Layer
+	Scale int8  `json:"scale"`
+	Value int32 `json:"value"` // e.g. meters above sea-level
+}
+
+// fixSigned Переводит масштабный множитель и значение поверхности из прямого кода
+func (s *Surface) fixSigned() {
+	s.Scale = signed8(s.Scale)
+	s.Value = signed32(s.Value)
 }
\ No newline at end of file
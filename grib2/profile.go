@@ -0,0 +1,562 @@
+package grib2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gribV2.com/config"
+)
+
+// Типы поверхностей (Code table 4.5), между которыми строятся профили
+const (
+	surfaceIsobaric uint8 = 100 // Изобарическая поверхность, Па
+	surfaceAltitude uint8 = 102 // Высота над средним уровнем моря, м
+)
+
+// heightParameter Геопотенциальная высота (HGT), по которой поля переводятся на высоты
+var heightParameter = parameterKey{0, 3, 5}
+
+// ErrOutsideProfile Уровень лежит выше или ниже всех уровней профиля
+var ErrOutsideProfile = errors.New("уровень вне профиля")
+
+// Level Возвращает значение первой поверхности поля: давление в Па, высоту в м и т. д. Для полей,
+// созданных не при чтении файла, значение берется из записи SurfaceValue
+func (t *Table) Level() (float64, bool) {
+	if t.surface.Type != 0 {
+		return t.surface.Float()
+	}
+	value, err := strconv.ParseFloat(strings.TrimSuffix(t.SurfaceValue, "m"), 64)
+	return value, err == nil
+}
+
+// surfaceIs Сообщает, что первая поверхность поля имеет тип surface
+func (t *Table) surfaceIs(surface uint8) bool {
+	if t.surface.Type != 0 {
+		return t.surface.Type == surface
+	}
+	return t.SurfaceType == ReadSurfaceTypesUnits(int(surface))
+}
+
+// setLevel Записывает в поле поверхность типа surface со значением value
+func (t *Table) setLevel(surface uint8, value float64) {
+	// Дробное значение записывается с наименьшим десятичным множителем, при котором оно целое,
+	// но не больше того, при котором масштабированное значение помещается в 32 бита
+	scale := int8(0)
+	for scale < 6 && math.Abs(value*math.Pow(10, float64(scale))-math.Round(value*math.Pow(10, float64(scale)))) > 1e-9 &&
+		math.Abs(value*math.Pow(10, float64(scale+1))) <= math.MaxInt32 {
+		scale++
+	}
+	t.surface = Surface{Type: surface, Scale: scale, Value: int32(math.Round(value * math.Pow(10, float64(scale))))}
+	t.SurfaceType = ReadSurfaceTypesUnits(int(surface))
+	t.SurfaceValue = fmt.Sprintf("%dm", t.surface.Value)
+}
+
+// Cube Поля одного параметра на всех уровнях поверхности одного типа для одного срока, сетки и
+// участника ансамбля. Уровни упорядочены снизу вверх: изобарические поверхности — по убыванию
+// давления, остальные — по возрастанию значения
+type Cube struct {
+	Levels []float64
+	Fields []*Table
+
+	pressure bool
+}
+
+// NewCube Собирает куб из полей одного параметра на разных уровнях. Поле, повторяющее уровень,
+// заменяет прежнее
+func NewCube(fields []*Table) (*Cube, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("нет полей для профиля")
+	}
+	first := fields[0]
+	grid, _ := gridKey(first.Section3.Sec3.Definition)
+	byLevel := map[float64]*Table{}
+	for _, field := range fields {
+		if fieldKey(field) != fieldKey(first) || field.SurfaceType != first.SurfaceType {
+			return nil, fmt.Errorf("поля %s и %s относятся к разным параметрам или поверхностям", first.Parameter.ShortName, field.Parameter.ShortName)
+		}
+		if !field.Date.Equal(first.Date) || !field.WindowEnd.Equal(first.WindowEnd) || field.EnsembleMember != first.EnsembleMember || field.EnsembleProduct != first.EnsembleProduct {
+			return nil, fmt.Errorf("поля %s относятся к разным срокам или участникам ансамбля", first.Parameter.ShortName)
+		}
+		if other, _ := gridKey(field.Section3.Sec3.Definition); other != grid || len(field.Data) != len(first.Data) {
+			return nil, fmt.Errorf("поля %s заданы на разных сетках", first.Parameter.ShortName)
+		}
+		level, ok := field.Level()
+		if !ok {
+			return nil, fmt.Errorf("у поля %s не указан уровень", field.Parameter.ShortName)
+		}
+		byLevel[level] = field
+	}
+	cube := &Cube{pressure: first.surfaceIs(surfaceIsobaric)}
+	for level := range byLevel {
+		cube.Levels = append(cube.Levels, level)
+	}
+	sort.Float64s(cube.Levels)
+	if cube.pressure {
+		sort.Sort(sort.Reverse(sort.Float64Slice(cube.Levels)))
+	}
+	for _, level := range cube.Levels {
+		cube.Fields = append(cube.Fields, byLevel[level])
+	}
+	return cube, nil
+}
+
+// Parameter Возвращает параметр куба
+func (c *Cube) Parameter() Parameter {
+	return c.Fields[0].Parameter
+}
+
+// coordinate Возвращает вертикальную координату уровня, по которой ведется интерполяция:
+// логарифм давления для изобарических поверхностей, иначе само значение
+func (c *Cube) coordinate(level float64) float64 {
+	if c.pressure {
+		return -math.Log(level)
+	}
+	return level
+}
+
+// bracket Возвращает номер нижнего из двух уровней, между которыми лежит level, и вес верхнего
+func (c *Cube) bracket(level float64) (int, float64, error) {
+	x := c.coordinate(level)
+	for k := range c.Levels {
+		if c.Levels[k] == level {
+			return k, 0, nil
+		}
+		if k+1 < len(c.Levels) {
+			x0, x1 := c.coordinate(c.Levels[k]), c.coordinate(c.Levels[k+1])
+			if x > x0 && x < x1 {
+				return k, (x - x0) / (x1 - x0), nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: %g, уровни от %g до %g", ErrOutsideProfile, level, c.Levels[0], c.Levels[len(c.Levels)-1])
+}
+
+// Level Возвращает поле на уровне level (для изобарических поверхностей — давление в Па). Между
+// изобарическими поверхностями значения интерполируются линейно по логарифму давления, между
+// остальными — линейно по значению уровня. Для уровней вне куба возвращает ErrOutsideProfile
+func (c *Cube) Level(level float64) (*Table, error) {
+	k, w, err := c.bracket(level)
+	if err != nil {
+		return nil, err
+	}
+	lower := c.Fields[k]
+	data := make(Values, len(lower.Data))
+	for j := range data {
+		data[j] = lower.Data[j]
+		if w == 0 {
+			continue
+		}
+		upper := c.Fields[k+1].Data[j]
+		if IsMissing(data[j]) || IsMissing(upper) {
+			data[j] = MissingValue
+			continue
+		}
+		data[j] += w * (upper - data[j])
+	}
+	field := lower.derive(data)
+	surface := lower.surface.Type
+	if surface == 0 && c.pressure {
+		surface = surfaceIsobaric
+	}
+	field.setLevel(surface, level)
+	if surface == 0 {
+		// Тип поверхности неизвестен, сохраняется описание исходного поля
+		field.SurfaceType = lower.SurfaceType
+	}
+	return field, nil
+}
+
+// Height Возвращает поле на высоте z в гпм над уровнем моря. Высоты уровней в каждой точке берутся
+// из куба геопотенциальных высот height на тех же уровнях и сетке, значения интерполируются
+// линейно по высоте. Точки, где z ниже нижнего или выше верхнего уровня, остаются пропущенными
+func (c *Cube) Height(height *Cube, z float64) (*Table, error) {
+	if len(height.Levels) != len(c.Levels) || len(height.Fields[0].Data) != len(c.Fields[0].Data) {
+		return nil, fmt.Errorf("высоты %s заданы не на тех же уровнях или сетке, что %s", height.Parameter().ShortName, c.Parameter().ShortName)
+	}
+	for k, level := range c.Levels {
+		if height.Levels[k] != level {
+			return nil, fmt.Errorf("высоты %s заданы не на тех же уровнях, что %s", height.Parameter().ShortName, c.Parameter().ShortName)
+		}
+	}
+	conversion, ok := Tables.Conversion(height.Parameter().Unit, "gpm")
+	if !ok {
+		return nil, fmt.Errorf("нет пересчета высот %s из %q в gpm", height.Parameter().ShortName, height.Parameter().Unit)
+	}
+	data := make(Values, len(c.Fields[0].Data))
+	for j := range data {
+		data[j] = MissingValue
+		for k := 0; k+1 < len(c.Levels); k++ {
+			h0, h1 := height.Fields[k].Data[j], height.Fields[k+1].Data[j]
+			if IsMissing(h0) || IsMissing(h1) {
+				continue
+			}
+			h0, h1 = conversion.Apply(h0), conversion.Apply(h1)
+			if z < math.Min(h0, h1) || z > math.Max(h0, h1) {
+				continue
+			}
+			v0, v1 := c.Fields[k].Data[j], c.Fields[k+1].Data[j]
+			if IsMissing(v0) || IsMissing(v1) {
+				break
+			}
+			data[j] = v0
+			if h1 != h0 {
+				data[j] += (z - h0) / (h1 - h0) * (v1 - v0)
+			}
+			break
+		}
+	}
+	field := c.Fields[0].derive(data)
+	field.setLevel(surfaceAltitude, z)
+	return field, nil
+}
+
+// Sounding Вертикальный профиль параметра в точке: значения на уровнях куба снизу вверх
+type Sounding struct {
+	Parameter   Parameter
+	SurfaceType string
+	Lat         float64
+	Lon         float64
+	Levels      []float64
+	Values      Values
+
+	pressure bool
+}
+
+// Sounding Возвращает профиль в точке lat, lon. Значение на каждом уровне вычисляется способом method
+func (c *Cube) Sounding(lat, lon float64, method Interpolation) (*Sounding, error) {
+	first := c.Fields[0]
+	projection, err := NewProjection(first.Section3.Sec3.Definition)
+	if err != nil {
+		return nil, err
+	}
+	ni, nj := projection.Size()
+	if len(first.Data) != ni*nj {
+		return nil, fmt.Errorf("количество значений %d не совпадает с размером сетки %dx%d", len(first.Data), ni, nj)
+	}
+	weights, err := pointWeights(projection, lat, lon, method)
+	if err != nil {
+		return nil, err
+	}
+	sounding := &Sounding{
+		Parameter:   first.Parameter,
+		SurfaceType: first.SurfaceType,
+		Lat:         lat,
+		Lon:         lon,
+		Levels:      append([]float64(nil), c.Levels...),
+		Values:      make(Values, len(c.Fields)),
+		pressure:    c.pressure,
+	}
+	for k, field := range c.Fields {
+		sounding.Values[k] = apply(weights, field.Data)
+	}
+	return sounding, nil
+}
+
+// At Возвращает значение профиля на уровне level: по логарифму давления для изобарических
+// поверхностей, иначе линейно. Вне профиля и между уровнями без значений возвращает MissingValue
+func (s *Sounding) At(level float64) float64 {
+	cube := &Cube{Levels: s.Levels, pressure: s.pressure}
+	k, w, err := cube.bracket(level)
+	if err != nil {
+		return MissingValue
+	}
+	if w == 0 {
+		return s.Values[k]
+	}
+	if IsMissing(s.Values[k]) || IsMissing(s.Values[k+1]) {
+		return MissingValue
+	}
+	return s.Values[k] + w*(s.Values[k+1]-s.Values[k])
+}
+
+// cubeKey Поля одного куба: файл, параметр, срок, тип поверхности, сетка и участник ансамбля
+type cubeKey struct {
+	timeKey
+	parameter parameterKey
+}
+
+// timeKey Кубы одного срока: файл, срок, тип поверхности, сетка и участник ансамбля
+type timeKey struct {
+	source                 string
+	date, start, valid     int64
+	process                uint8
+	surfaceType            string
+	grid                   interface{}
+	points                 int
+	ensembleType, ensemble uint8
+	product                string
+}
+
+// ProfileStage Этап построения вертикальных профилей. Поля изобарических поверхностей параметров
+// Parameters (всех параметров, если список пуст) проходят дальше без изменений и накапливаются
+// до конца файла. Затем из них собираются кубы, из которых добавляются поля на уровнях Levels
+// (давление в Па, уже прочитанные уровни пропускаются) и высотах Heights (гпм над уровнем моря,
+// по кубу HGT), а профили в точках Points записываются json-файлами в каталог Dir
+type ProfileStage struct {
+	Parameters []string
+	Levels     []float64
+	Heights    []float64
+	Points     [][2]float64
+	Dir        string
+	Method     Interpolation
+
+	mu    sync.Mutex
+	cubes map[cubeKey][]*Table
+}
+
+// ParseProfiles Создает этап по списку параметров через запятую ("TMP,RH"), уровням через
+// запятую — давлению в гПа или высоте в метрах с суффиксом m ("925,850,1500m") — и точкам
+// "широта,долгота" через ";"
+func ParseProfiles(parameters, levels, points, dir string) (*ProfileStage, error) {
+	stage := &ProfileStage{Dir: dir, Method: Bilinear}
+	for _, name := range strings.Split(parameters, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			stage.Parameters = append(stage.Parameters, name)
+		}
+	}
+	for _, level := range strings.Split(levels, ",") {
+		level = strings.TrimSpace(level)
+		if level == "" {
+			continue
+		}
+		if height, ok := strings.CutSuffix(level, "m"); ok {
+			value, err := strconv.ParseFloat(strings.TrimSpace(height), 64)
+			if err != nil {
+				return nil, fmt.Errorf("уровень %q: %w", level, err)
+			}
+			stage.Heights = append(stage.Heights, value)
+			continue
+		}
+		value, err := strconv.ParseFloat(level, 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("уровень %q: ожидается давление в гПа или высота в м", level)
+		}
+		stage.Levels = append(stage.Levels, value*100)
+	}
+	for _, point := range strings.Split(points, ";") {
+		point = strings.TrimSpace(point)
+		if point == "" {
+			continue
+		}
+		lat, lon, ok := strings.Cut(point, ",")
+		if !ok {
+			return nil, fmt.Errorf("точка %q: ожидается широта,долгота", point)
+		}
+		latValue, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		if err != nil || latValue < -90 || latValue > 90 {
+			return nil, fmt.Errorf("точка %q: некорректная широта", point)
+		}
+		lonValue, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if err != nil {
+			return nil, fmt.Errorf("точка %q: некорректная долгота", point)
+		}
+		stage.Points = append(stage.Points, [2]float64{latValue, lonValue})
+	}
+	if len(stage.Points) > 0 && dir == "" {
+		return nil, errors.New("не указан каталог для профилей в точках")
+	}
+	return stage, nil
+}
+
+// requested Сообщает, что для параметра нужно строить профили
+func (s *ProfileStage) requested(parameter Parameter) bool {
+	if len(s.Parameters) == 0 {
+		return true
+	}
+	for _, name := range s.Parameters {
+		if name == parameter.ShortName {
+			return true
+		}
+	}
+	return false
+}
+
+// Process Пропускает поле дальше и запоминает поля изобарических поверхностей для профилей
+func (s *ProfileStage) Process(field *Table) []*Table {
+	if !field.surfaceIs(surfaceIsobaric) || field.Parameter.Categorical() {
+		return []*Table{field}
+	}
+	// Высоты HGT нужны для перевода на высоты, даже если для них профили не строятся
+	if !s.requested(field.Parameter) && !(len(s.Heights) > 0 && fieldKey(field) == heightParameter) {
+		return []*Table{field}
+	}
+	grid, _ := gridKey(field.Section3.Sec3.Definition)
+	key := cubeKey{
+		timeKey: timeKey{
+			source:       field.source,
+			date:         field.Date.Unix(),
+			start:        field.WindowStart.Unix(),
+			valid:        field.WindowEnd.Unix(),
+			process:      field.StatisticalProcess,
+			surfaceType:  field.SurfaceType,
+			grid:         grid,
+			points:       len(field.Data),
+			ensembleType: field.EnsembleType,
+			ensemble:     field.EnsembleMember,
+			product:      field.EnsembleProduct,
+		},
+		parameter: fieldKey(field),
+	}
+	s.mu.Lock()
+	if s.cubes == nil {
+		s.cubes = map[cubeKey][]*Table{}
+	}
+	s.cubes[key] = append(s.cubes[key], field)
+	s.mu.Unlock()
+	return []*Table{field}
+}
+
+// FlushSource Строит профили по полям файла source
+func (s *ProfileStage) FlushSource(source string) []*Table {
+	return s.flush(func(key cubeKey) bool { return key.source == source })
+}
+
+// Flush Строит профили по всем оставшимся полям
+func (s *ProfileStage) Flush() []*Table {
+	return s.flush(func(cubeKey) bool { return true })
+}
+
+// flush Строит кубы из отобранных полей, группирует их по срокам и выдает поля на новых уровнях
+func (s *ProfileStage) flush(selected func(cubeKey) bool) []*Table {
+	s.mu.Lock()
+	times := map[timeKey][]*Cube{}
+	for key, fields := range s.cubes {
+		if !selected(key) {
+			continue
+		}
+		delete(s.cubes, key)
+		cube, err := NewCube(fields)
+		if err != nil {
+			config.Logger.WithError(err).Warn("Профиль не построен")
+			continue
+		}
+		times[key.timeKey] = append(times[key.timeKey], cube)
+	}
+	s.mu.Unlock()
+	var fields []*Table
+	for _, cubes := range times {
+		sort.Slice(cubes, func(i, j int) bool { return cubes[i].Parameter().ShortName < cubes[j].Parameter().ShortName })
+		fields = append(fields, s.levels(cubes)...)
+		if len(s.Points) > 0 {
+			if err := s.saveSoundings(cubes); err != nil {
+				config.Logger.WithError(err).Error("Ошибка записи профилей")
+			}
+		}
+	}
+	return fields
+}
+
+// levels Возвращает поля кубов одного срока на уровнях Levels и высотах Heights
+func (s *ProfileStage) levels(cubes []*Cube) []*Table {
+	var height *Cube
+	for _, cube := range cubes {
+		if fieldKey(cube.Fields[0]) == heightParameter {
+			height = cube
+		}
+	}
+	var fields []*Table
+	for _, cube := range cubes {
+		if !s.requested(cube.Parameter()) {
+			continue
+		}
+		for _, level := range s.Levels {
+			if _, _, err := cube.bracket(level); err != nil || cube.has(level) {
+				continue
+			}
+			field, err := cube.Level(level)
+			if err != nil {
+				config.Logger.WithError(err).WithField("parameter", cube.Parameter().ShortName).Warn("Поле на уровне не вычислено")
+				continue
+			}
+			fields = append(fields, field)
+		}
+		if len(s.Heights) == 0 {
+			continue
+		}
+		if height == nil {
+			config.Logger.WithField("parameter", cube.Parameter().ShortName).Warn("Нет высот HGT, поля на высотах не вычислены")
+			continue
+		}
+		for _, z := range s.Heights {
+			field, err := cube.Height(height, z)
+			if err != nil {
+				config.Logger.WithError(err).WithField("parameter", cube.Parameter().ShortName).Warn("Поле на высоте не вычислено")
+				break
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// has Сообщает, что в кубе есть уровень level
+func (c *Cube) has(level float64) bool {
+	for _, value := range c.Levels {
+		if value == level {
+			return true
+		}
+	}
+	return false
+}
+
+// soundingRecord Профили всех параметров одного срока в точке, записываемые в json-файл
+type soundingRecord struct {
+	Date           time.Time
+	WindowEnd      time.Time
+	Lat            float64
+	Lon            float64
+	EnsembleMember uint8 `json:",omitempty"`
+	Profiles       []*Sounding
+}
+
+// saveSoundings Записывает профили кубов одного срока в точках Points в файлы
+// <Dir>/<срок>/<шаг>/sounding_<широта>_<долгота>.json
+func (s *ProfileStage) saveSoundings(cubes []*Cube) error {
+	first := cubes[0].Fields[0]
+	for _, point := range s.Points {
+		record := soundingRecord{Date: first.Date, WindowEnd: first.WindowEnd, Lat: point[0], Lon: point[1], EnsembleMember: first.EnsembleMember}
+		for _, cube := range cubes {
+			if !s.requested(cube.Parameter()) {
+				continue
+			}
+			sounding, err := cube.Sounding(point[0], point[1], s.Method)
+			if err != nil {
+				if errors.Is(err, ErrOutsideGrid) {
+					continue
+				}
+				return err
+			}
+			record.Profiles = append(record.Profiles, sounding)
+		}
+		if len(record.Profiles) == 0 {
+			continue
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		dir, err := createFolder(tableFolder(s.Dir, first))
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("sounding_%s_%s", strconv.FormatFloat(point[0], 'f', -1, 64), strconv.FormatFloat(point[1], 'f', -1, 64))
+		if first.EnsembleProduct != "" {
+			name += "_" + first.EnsembleProduct
+		} else if first.EnsembleType != EnsembleNone {
+			name += fmt.Sprintf("_m%d", first.EnsembleMember)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
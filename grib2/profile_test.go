@@ -0,0 +1,117 @@
+package grib2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// levelField Возвращает поле параметра category.number на изобарической поверхности pressure (Па)
+// на сетке 2x1 со значениями data
+func levelField(t *testing.T, category, number uint8, pressure int32, data Values) *Table {
+	t.Helper()
+	return testField{ni: 2, nj: 1, category: category, number: number, surface: &Surface{Type: surfaceIsobaric, Value: pressure}, data: data}.table(t)
+}
+
+func TestCubeLevel(t *testing.T) {
+	// Уровни передаются не по порядку, повторный уровень 500 гПа заменяет прежний
+	cube, err := NewCube([]*Table{
+		levelField(t, 0, 0, 50000, Values{0, 0}),
+		levelField(t, 0, 0, 100000, Values{0, 20}),
+		levelField(t, 0, 0, 50000, Values{100, MissingValue}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cube.Levels) != 2 || cube.Levels[0] != 100000 || cube.Levels[1] != 50000 {
+		t.Fatalf("уровни %v, ожидались [100000 50000]", cube.Levels)
+	}
+	// Вес верхнего уровня — доля логарифма давления
+	weight := func(p float64) float64 { return math.Log(100000/p) / math.Log(2) }
+	tests := []struct {
+		name  string
+		level float64
+		want  Values
+		err   error
+	}{
+		{"нижний уровень", 100000, Values{0, 20}, nil},
+		{"верхний уровень", 50000, Values{100, MissingValue}, nil},
+		{"середина по логарифму давления", math.Sqrt(100000 * 50000), Values{50, MissingValue}, nil},
+		{"850 гПа", 85000, Values{100 * weight(85000), MissingValue}, nil},
+		{"выше профиля", 30000, nil, ErrOutsideProfile},
+		{"ниже профиля", 105000, nil, ErrOutsideProfile},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := cube.Level(test.level)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			for k := range test.want {
+				if IsMissing(test.want[k]) != IsMissing(field.Data[k]) || math.Abs(field.Data[k]-test.want[k]) > 1e-9 {
+					t.Fatalf("значения %v, ожидались %v", field.Data, test.want)
+				}
+			}
+			if level, ok := field.Level(); !ok || math.Abs(level-test.level) > 1e-3 || field.SurfaceType != cube.Fields[0].SurfaceType {
+				t.Fatalf("уровень %s %g, ожидался %g", field.SurfaceType, level, test.level)
+			}
+		})
+	}
+
+	sounding, err := cube.Sounding(60, 0, Nearest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := sounding.At(85000); math.Abs(value-100*weight(85000)) > 1e-9 {
+		t.Fatalf("значение профиля на 850 гПа %g, ожидалось %g", value, 100*weight(85000))
+	}
+	if value := sounding.At(30000); !IsMissing(value) {
+		t.Fatalf("значение профиля выше верхнего уровня %g, ожидался пропуск", value)
+	}
+}
+
+func TestCubeHeight(t *testing.T) {
+	temperature, err := NewCube([]*Table{
+		levelField(t, 0, 0, 100000, Values{0, 20}),
+		levelField(t, 0, 0, 50000, Values{100, 40}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	height, err := NewCube([]*Table{
+		levelField(t, 3, 5, 100000, Values{100, 200}),
+		levelField(t, 3, 5, 50000, Values{5500, 5600}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		z    float64
+		want Values
+	}{
+		{"между уровнями", 2800, Values{50, 20 + 20*2600.0/5400}},
+		{"на нижнем уровне", 100, Values{0, MissingValue}},
+		{"ниже профиля в одной точке", 150, Values{50.0 / 54, MissingValue}},
+		{"выше профиля", 6000, Values{MissingValue, MissingValue}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := temperature.Height(height, test.z)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k := range test.want {
+				if IsMissing(test.want[k]) != IsMissing(field.Data[k]) || math.Abs(field.Data[k]-test.want[k]) > 1e-9 {
+					t.Fatalf("значения %v, ожидались %v", field.Data, test.want)
+				}
+			}
+			if level, ok := field.Level(); !ok || level != test.z {
+				t.Fatalf("уровень %g, ожидался %g", level, test.z)
+			}
+		})
+	}
+}
@@ -0,0 +1,632 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Флаги режима сканирования (Flag table 3.4)
+const (
+	scanNegativeI     uint8 = 0x80 // Точки строки идут в отрицательном направлении x (с востока на запад)
+	scanPositiveJ     uint8 = 0x40 // Строки идут в положительном направлении y (с юга на север)
+	scanConsecutiveJ  uint8 = 0x20 // Подряд записаны точки столбца, а не строки
+	scanBoustrophedon uint8 = 0x10 // Направление сканирования четных и нечетных строк чередуется
+)
+
+// Projection Связь географических координат с узлами сетки. Индекс i отсчитывается от первой точки
+// вдоль параллели (оси x проекции), j — вдоль меридиана (оси y) в направлениях, заданных режимом
+// сканирования. Сферическая Земля берется с радиусом из шаблона, эллипсоид заменяется сферой
+// с радиусом большой полуоси
+type Projection interface {
+	// Index Возвращает дробные индексы точки. Второе значение false, если точку нельзя спроецировать
+	// (например, она не видна со спутника). Индексы точек вне области выходят за пределы сетки
+	Index(lat, lon float64) (i, j float64, ok bool)
+	// LatLon Возвращает координаты точки с дробными индексами i, j, долгота в диапазоне [-180, 180)
+	LatLon(i, j float64) (lat, lon float64)
+	// Size Возвращает количество точек вдоль i и j
+	Size() (ni, nj int)
+	// Periodic Сообщает, что сетка замкнута по долготе: за последней точкой строки следует первая
+	Periodic() bool
+	// Offset Возвращает номер узла i, j в массиве значений
+	Offset(i, j int) int
+}
+
+// projections Кэш проекций по значению определения сетки
+var projections sync.Map
+
+// NewProjection Создает проекцию по определению сетки из Секции 3
+func NewProjection(definition interface{}) (Projection, error) {
+	key, ok := gridKey(definition)
+	if !ok {
+		return nil, fmt.Errorf("%w: проекция сетки %T", ErrUnsupportedTemplate, definition)
+	}
+	if projection, ok := projections.Load(key); ok {
+		return projection.(Projection), nil
+	}
+	projection, err := newProjection(definition)
+	if err != nil {
+		return nil, err
+	}
+	projections.Store(key, projection)
+	return projection, nil
+}
+
+// gridKey Возвращает определение сетки по значению для использования в качестве ключа кэша
+func gridKey(definition interface{}) (interface{}, bool) {
+	switch grid := definition.(type) {
+	case *Grid0:
+		return *grid, true
+	case *Grid10:
+		return *grid, true
+	case *Grid20:
+		return *grid, true
+	case *Grid30:
+		return *grid, true
+	case *Grid40:
+		return *grid, true
+	case *Grid90:
+		return *grid, true
+	}
+	return nil, false
+}
+
+// newProjection Создает проекцию без обращения к кэшу
+func newProjection(definition interface{}) (Projection, error) {
+	switch grid := definition.(type) {
+	case *Grid0:
+		unit := angleUnit(grid.BasicAngle)
+		return newLatLonProjection(grid.Ni, grid.Nj, grid.ScanningMode, float64(grid.La1)*unit, float64(grid.Lo1)*unit, float64(grid.La2)*unit, float64(grid.Lo2)*unit, nil)
+	case *Grid40:
+		unit := angleUnit(grid.BasicAngle)
+		la1, la2 := float64(grid.La1)*unit, float64(grid.La2)*unit
+		rows, err := gaussianRows(grid.N, grid.Nj, la1, la2)
+		if err != nil {
+			return nil, err
+		}
+		return newLatLonProjection(grid.Ni, grid.Nj, grid.ScanningMode, la1, float64(grid.Lo1)*unit, la2, float64(grid.Lo2)*unit, rows)
+	case *Grid10:
+		if grid.Nj <= 0 {
+			return nil, fmt.Errorf("%w: некорректное количество строк сетки %d", ErrCorruptData, grid.Nj)
+		}
+		lo1, lo2 := microdegrees(grid.Lo1), microdegrees(grid.Lo2)
+		// Долготы отсчитываются от середины области, чтобы сетки через 180° не разрывались
+		span := math.Mod(lo2-lo1+720, 360)
+		if grid.ScanningMode&scanNegativeI != 0 {
+			span -= 360
+		}
+		plane := &mercator{
+			radius: earthRadius(grid.GridHeader),
+			scale:  math.Cos(radians(microdegrees(grid.Lad))),
+			center: lo1 + span/2,
+		}
+		return newPlaneProjection(plane, grid.Ni, uint32(grid.Nj), grid.ScanningMode, microdegrees(grid.La1), lo1, float64(grid.Di)/1000, float64(grid.Dj)/1000)
+	case *Grid20:
+		plane := &stereographic{
+			radius: earthRadius(grid.GridHeader),
+			lad:    radians(microdegrees(grid.Lad)),
+			lov:    microdegrees(grid.Lov),
+			south:  grid.ProjectionCenter&0x80 != 0,
+		}
+		return newPlaneProjection(plane, grid.Nx, grid.Ny, grid.ScanningMode, microdegrees(grid.La1), microdegrees(grid.Lo1), float64(grid.Dx)/1000, float64(grid.Dy)/1000)
+	case *Grid30:
+		plane := newLambert(earthRadius(grid.GridHeader), microdegrees(grid.Latin1), microdegrees(grid.Latin2), microdegrees(grid.Lov))
+		return newPlaneProjection(plane, grid.Nx, grid.Ny, grid.ScanningMode, microdegrees(grid.La1), microdegrees(grid.Lo1), float64(grid.Dx)/1000, float64(grid.Dy)/1000)
+	case *Grid90:
+		return newSpaceView(grid)
+	}
+	return nil, fmt.Errorf("%w: проекция сетки %T", ErrUnsupportedTemplate, definition)
+}
+
+// scan Размер сетки и порядок записи ее узлов
+type scan struct {
+	ni, nj int
+	mode   uint8
+}
+
+// Size Возвращает количество точек вдоль i и j
+func (s scan) Size() (int, int) {
+	return s.ni, s.nj
+}
+
+// Offset Возвращает номер узла i, j в массиве значений с учетом режима сканирования
+func (s scan) Offset(i, j int) int {
+	if s.mode&scanConsecutiveJ != 0 {
+		if s.mode&scanBoustrophedon != 0 && i%2 == 1 {
+			j = s.nj - 1 - j
+		}
+		return i*s.nj + j
+	}
+	if s.mode&scanBoustrophedon != 0 && j%2 == 1 {
+		i = s.ni - 1 - i
+	}
+	return j*s.ni + i
+}
+
+// latLonProjection Широтно-долготная сетка (шаблоны 3.0 и 3.40)
+type latLonProjection struct {
+	scan
+	lat1, dlat float64
+	lon1, dlon float64
+	// Широты строк Гауссовой сетки, nil для равномерной сетки
+	rows     []float64
+	periodic bool
+}
+
+// newLatLonProjection Создает широтно-долготную сетку по координатам первой и последней точек
+func newLatLonProjection(ni, nj uint32, mode uint8, la1, lo1, la2, lo2 float64, rows []float64) (Projection, error) {
+	if ni == 0 || nj == 0 {
+		return nil, fmt.Errorf("%w: сетка %dx%d без точек", ErrCorruptData, ni, nj)
+	}
+	p := &latLonProjection{scan: scan{ni: int(ni), nj: int(nj), mode: mode}, lat1: la1, lon1: lo1, rows: rows}
+	if nj > 1 {
+		p.dlat = (la2 - la1) / float64(nj-1)
+	}
+	if ni > 1 {
+		if mode&scanNegativeI != 0 {
+			p.dlon = -math.Mod(lo1-lo2+720, 360) / float64(ni-1)
+		} else {
+			p.dlon = math.Mod(lo2-lo1+720, 360) / float64(ni-1)
+		}
+		p.periodic = p.dlon != 0 && math.Abs(float64(ni)*math.Abs(p.dlon)-360) < math.Abs(p.dlon)/2
+	}
+	return p, nil
+}
+
+// Periodic Сообщает, что сетка замкнута по долготе
+func (p *latLonProjection) Periodic() bool {
+	return p.periodic
+}
+
+// Index Возвращает дробные индексы точки
+func (p *latLonProjection) Index(lat, lon float64) (float64, float64, bool) {
+	i := 0.0
+	if p.dlon != 0 {
+		offset := lon - p.lon1
+		if p.dlon < 0 {
+			offset = -offset
+		}
+		i = math.Mod(math.Mod(offset, 360)+360, 360) / math.Abs(p.dlon)
+		// Точка западнее первой точки (восточнее при обратном сканировании) выходит за сетку с другой стороны
+		if !p.periodic && i > float64(p.ni-1) {
+			if before := i - 360/math.Abs(p.dlon); -before < i-float64(p.ni-1) {
+				i = before
+			}
+		}
+	} else if math.Mod(math.Abs(lon-p.lon1), 360) > 1e-9 {
+		i = math.Inf(1)
+	}
+	var j float64
+	switch {
+	case p.rows != nil:
+		j = fractionalIndex(p.rows, lat)
+	case p.dlat != 0:
+		j = (lat - p.lat1) / p.dlat
+	case math.Abs(lat-p.lat1) > 1e-9:
+		j = math.Inf(1)
+	}
+	// Глобальная сетка покрывает полярные шапки за крайними строками, если они ближе шага сетки к полюсу
+	if p.periodic && (j < 0 || j > float64(p.nj-1)) {
+		edge := math.Max(0, math.Min(j, float64(p.nj-1)))
+		edgeLat, _ := p.LatLon(0, edge)
+		if 90-math.Abs(edgeLat) <= 180/float64(p.nj)+indexEpsilon {
+			j = edge
+		}
+	}
+	return i, j, true
+}
+
+// LatLon Возвращает координаты точки с дробными индексами
+func (p *latLonProjection) LatLon(i, j float64) (float64, float64) {
+	lat := p.lat1 + j*p.dlat
+	if p.rows != nil {
+		lat = fractionalValue(p.rows, j)
+	}
+	return lat, normalizeLongitude(p.lon1 + i*p.dlon)
+}
+
+// fractionalIndex Возвращает дробный номер значения в монотонном массиве, за его пределами — по крайнему шагу
+func fractionalIndex(values []float64, value float64) float64 {
+	n := len(values)
+	if n == 1 {
+		if value == values[0] {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	ascending := values[n-1] > values[0]
+	// Первый промежуток, правая граница которого не раньше значения
+	k := sort.Search(n-2, func(k int) bool {
+		if ascending {
+			return values[k+1] >= value
+		}
+		return values[k+1] <= value
+	})
+	return float64(k) + (value-values[k])/(values[k+1]-values[k])
+}
+
+// fractionalValue Возвращает значение монотонного массива для дробного номера
+func fractionalValue(values []float64, index float64) float64 {
+	n := len(values)
+	if n == 1 {
+		return values[0]
+	}
+	k := int(math.Floor(index))
+	k = max(0, min(k, n-2))
+	return values[k] + (index-float64(k))*(values[k+1]-values[k])
+}
+
+// gaussianLatitudes Кэш широт глобальных Гауссовых сеток по количеству параллелей между полюсом и экватором
+var gaussianLatitudes sync.Map
+
+// gaussian Возвращает широты 2n параллелей Гауссовой сетки с севера на юг — нули многочлена Лежандра степени 2n
+func gaussian(n int) []float64 {
+	if lats, ok := gaussianLatitudes.Load(n); ok {
+		return lats.([]float64)
+	}
+	total := 2 * n
+	lats := make([]float64, total)
+	for k := 0; k < n; k++ {
+		x := math.Cos(math.Pi * (float64(k) + 0.75) / (float64(total) + 0.5))
+		for iteration := 0; iteration < 100; iteration++ {
+			previous, current := 1.0, x
+			for l := 2; l <= total; l++ {
+				previous, current = current, ((2*float64(l)-1)*x*current-(float64(l)-1)*previous)/float64(l)
+			}
+			derivative := float64(total) * (x*current - previous) / (x*x - 1)
+			step := current / derivative
+			x -= step
+			if math.Abs(step) < 1e-15 {
+				break
+			}
+		}
+		lats[k] = degrees(math.Asin(x))
+		lats[total-1-k] = -lats[k]
+	}
+	gaussianLatitudes.Store(n, lats)
+	return lats
+}
+
+// gaussianRows Возвращает широты строк Гауссовой сетки от первой до последней точки
+func gaussianRows(n uint32, nj uint32, la1, la2 float64) ([]float64, error) {
+	if n == 0 || n > 1<<14 {
+		return nil, fmt.Errorf("%w: некорректное количество параллелей Гауссовой сетки %d", ErrCorruptData, n)
+	}
+	lats := gaussian(int(n))
+	first, last := nearestIndex(lats, la1), nearestIndex(lats, la2)
+	step := 1
+	if last < first {
+		step = -1
+	}
+	if (last-first)*step+1 != int(nj) {
+		return nil, fmt.Errorf("%w: широты %g..%g не соответствуют %d строкам Гауссовой сетки N=%d", ErrCorruptData, la1, la2, nj, n)
+	}
+	rows := make([]float64, 0, nj)
+	for k := first; k != last+step; k += step {
+		rows = append(rows, lats[k])
+	}
+	return rows, nil
+}
+
+// nearestIndex Возвращает номер ближайшего значения
+func nearestIndex(values []float64, value float64) int {
+	best := 0
+	for k := range values {
+		if math.Abs(values[k]-value) < math.Abs(values[best]-value) {
+			best = k
+		}
+	}
+	return best
+}
+
+// plane Картографическая проекция на плоскость: координаты в метрах (для вида из космоса — в радианах угла обзора)
+type plane interface {
+	forward(lat, lon float64) (x, y float64, ok bool)
+	inverse(x, y float64) (lat, lon float64, ok bool)
+}
+
+// planeProjection Сетка с постоянным шагом на плоскости проекции (шаблоны 3.10, 3.20, 3.30, 3.90)
+type planeProjection struct {
+	scan
+	plane
+	x1, y1 float64
+	// Шаги вдоль i и j со знаком направления сканирования
+	dx, dy float64
+}
+
+// newPlaneProjection Создает сетку по первой точке и шагам в метрах
+func newPlaneProjection(p plane, ni, nj uint32, mode uint8, la1, lo1, dx, dy float64) (Projection, error) {
+	if ni == 0 || nj == 0 {
+		return nil, fmt.Errorf("%w: сетка %dx%d без точек", ErrCorruptData, ni, nj)
+	}
+	if dx <= 0 || dy <= 0 {
+		return nil, fmt.Errorf("%w: некорректный шаг сетки %gx%g м", ErrCorruptData, dx, dy)
+	}
+	x1, y1, ok := p.forward(la1, lo1)
+	if !ok {
+		return nil, fmt.Errorf("%w: первая точка сетки %g, %g не проецируется", ErrCorruptData, la1, lo1)
+	}
+	return &planeProjection{scan: scan{ni: int(ni), nj: int(nj), mode: mode}, plane: p, x1: x1, y1: y1, dx: directed(dx, mode&scanNegativeI == 0), dy: directed(dy, mode&scanPositiveJ != 0)}, nil
+}
+
+// directed Возвращает шаг со знаком направления
+func directed(step float64, positive bool) float64 {
+	if positive {
+		return step
+	}
+	return -step
+}
+
+// Periodic Сетки на плоскости не замыкаются по долготе
+func (p *planeProjection) Periodic() bool {
+	return false
+}
+
+// Index Возвращает дробные индексы точки
+func (p *planeProjection) Index(lat, lon float64) (float64, float64, bool) {
+	x, y, ok := p.forward(lat, lon)
+	if !ok {
+		return 0, 0, false
+	}
+	return (x - p.x1) / p.dx, (y - p.y1) / p.dy, true
+}
+
+// LatLon Возвращает координаты точки с дробными индексами
+func (p *planeProjection) LatLon(i, j float64) (float64, float64) {
+	lat, lon, ok := p.inverse(p.x1+i*p.dx, p.y1+j*p.dy)
+	if !ok {
+		return math.NaN(), math.NaN()
+	}
+	return lat, normalizeLongitude(lon)
+}
+
+// mercator Проекция Меркатора с масштабом, истинным на широте LaD
+type mercator struct {
+	radius, scale float64
+	// Долгота середины области
+	center float64
+}
+
+func (m *mercator) forward(lat, lon float64) (float64, float64, bool) {
+	if math.Abs(lat) >= 90 {
+		return 0, 0, false
+	}
+	x := m.radius * m.scale * radians(normalizeLongitude(lon-m.center))
+	y := m.radius * m.scale * math.Log(math.Tan(math.Pi/4+radians(lat)/2))
+	return x, y, true
+}
+
+func (m *mercator) inverse(x, y float64) (float64, float64, bool) {
+	lat := degrees(2*math.Atan(math.Exp(y/(m.radius*m.scale))) - math.Pi/2)
+	return lat, m.center + degrees(x/(m.radius*m.scale)), true
+}
+
+// stereographic Полярная стереографическая проекция с масштабом, истинным на широте LaD
+type stereographic struct {
+	radius, lad, lov float64
+	// Плоскость касается южного полюса
+	south bool
+}
+
+func (s *stereographic) forward(lat, lon float64) (float64, float64, bool) {
+	phi, phi0 := radians(lat), s.lad
+	if s.south {
+		phi, phi0 = -phi, -phi0
+	}
+	if 1+math.Sin(phi) < 1e-12 {
+		return 0, 0, false
+	}
+	rho := s.radius * (1 + math.Sin(phi0)) * math.Cos(phi) / (1 + math.Sin(phi))
+	theta := radians(normalizeLongitude(lon - s.lov))
+	if s.south {
+		return rho * math.Sin(theta), rho * math.Cos(theta), true
+	}
+	return rho * math.Sin(theta), -rho * math.Cos(theta), true
+}
+
+func (s *stereographic) inverse(x, y float64) (float64, float64, bool) {
+	phi0 := s.lad
+	if s.south {
+		phi0 = -phi0
+		y = -y
+	}
+	rho := math.Hypot(x, y)
+	phi := math.Pi/2 - 2*math.Atan(rho/(s.radius*(1+math.Sin(phi0))))
+	lon := s.lov + degrees(math.Atan2(x, -y))
+	if s.south {
+		phi = -phi
+	}
+	return degrees(phi), lon, true
+}
+
+// lambert Коническая проекция Ламберта с двумя стандартными параллелями
+type lambert struct {
+	radius, n, f, lov float64
+}
+
+// newLambert Создает коническую проекцию Ламберта по стандартным параллелям latin1, latin2
+func newLambert(radius, latin1, latin2, lov float64) *lambert {
+	phi1, phi2 := radians(latin1), radians(latin2)
+	n := math.Sin(phi1)
+	if math.Abs(phi1-phi2) > 1e-10 {
+		n = math.Log(math.Cos(phi1)/math.Cos(phi2)) / math.Log(math.Tan(math.Pi/4+phi2/2)/math.Tan(math.Pi/4+phi1/2))
+	}
+	return &lambert{radius: radius, n: n, f: math.Cos(phi1) * math.Pow(math.Tan(math.Pi/4+phi1/2), n) / n, lov: lov}
+}
+
+func (l *lambert) forward(lat, lon float64) (float64, float64, bool) {
+	t := math.Tan(math.Pi/4 + radians(lat)/2)
+	if t <= 0 || math.IsInf(t, 0) {
+		return 0, 0, false
+	}
+	rho := l.radius * l.f / math.Pow(t, l.n)
+	if math.IsInf(rho, 0) {
+		return 0, 0, false
+	}
+	theta := l.n * radians(normalizeLongitude(lon-l.lov))
+	return rho * math.Sin(theta), -rho * math.Cos(theta), true
+}
+
+func (l *lambert) inverse(x, y float64) (float64, float64, bool) {
+	sign := math.Copysign(1, l.n)
+	rho := sign * math.Hypot(x, y)
+	if rho == 0 {
+		return sign * 90, l.lov, true
+	}
+	theta := math.Atan2(sign*x, -sign*y)
+	phi := 2*math.Atan(math.Pow(l.radius*l.f/rho, 1/l.n)) - math.Pi/2
+	return degrees(phi), l.lov + degrees(theta/l.n), true
+}
+
+// spaceView Вид с геостационарного спутника (шаблон 3.90). Координаты на плоскости — углы сканирования
+// в радианах, для ортографической проекции (расстояние не указано) — метры
+type spaceView struct {
+	radius float64
+	// Расстояние от центра Земли до спутника, 0 для ортографической проекции
+	distance float64
+	lop      float64
+	// Поворот осей сетки относительно меридиана
+	sin, cos float64
+}
+
+// newSpaceView Создает сетку вида из космоса
+func newSpaceView(grid *Grid90) (Projection, error) {
+	if grid.Lap != 0 {
+		return nil, fmt.Errorf("%w: вид из космоса со спутника вне экватора (широта %g)", ErrUnsupportedTemplate, microdegrees(grid.Lap))
+	}
+	if grid.Dx == 0 || grid.Dy == 0 {
+		return nil, fmt.Errorf("%w: нулевой видимый диаметр Земли", ErrCorruptData)
+	}
+	radius := earthRadius(grid.GridHeader)
+	view := &spaceView{radius: radius, lop: microdegrees(grid.Lop), cos: 1}
+	if grid.Orientation != math.MaxUint32 && grid.Orientation != 0 {
+		angle := radians(float64(grid.Orientation) * 1e-6)
+		view.sin, view.cos = math.Sin(angle), math.Cos(angle)
+	}
+	// Угловой (или линейный) размер шага сетки по видимому диаметру Земли
+	diameter := 2 * radius
+	if grid.Nr != math.MaxUint32 && grid.Nr != 0 {
+		view.distance = float64(grid.Nr) * 1e-6 * radius
+		if view.distance <= radius {
+			return nil, fmt.Errorf("%w: спутник ниже поверхности Земли", ErrCorruptData)
+		}
+		diameter = 2 * math.Asin(radius/view.distance)
+	}
+	rx, ry := diameter/float64(grid.Dx), diameter/float64(grid.Dy)
+	sx, sy := directed(1, grid.ScanningMode&scanNegativeI == 0), directed(1, grid.ScanningMode&scanPositiveJ != 0)
+	// Подспутниковая точка имеет координаты Xp, Yp (в тысячных долях шага), сектор начинается с Xo, Yo
+	xp, yp := float64(grid.Xp)/1000, float64(grid.Yp)/1000
+	return &planeProjection{
+		scan:  scan{ni: int(grid.Nx), nj: int(grid.Ny), mode: grid.ScanningMode},
+		plane: view,
+		x1:    sx * (float64(grid.Xo) - xp) * rx,
+		y1:    sy * (float64(grid.Yo) - yp) * ry,
+		dx:    sx * rx,
+		dy:    sy * ry,
+	}, nil
+}
+
+func (v *spaceView) forward(lat, lon float64) (float64, float64, bool) {
+	phi, lambda := radians(lat), radians(normalizeLongitude(lon-v.lop))
+	// Точка на обратной стороне Земли не видна
+	visible := math.Cos(phi) * math.Cos(lambda)
+	var x, y float64
+	if v.distance == 0 {
+		if visible < 0 {
+			return 0, 0, false
+		}
+		x, y = v.radius*math.Cos(phi)*math.Sin(lambda), v.radius*math.Sin(phi)
+	} else {
+		if visible < v.radius/v.distance {
+			return 0, 0, false
+		}
+		r1 := v.distance - v.radius*visible
+		r2 := v.radius * math.Cos(phi) * math.Sin(lambda)
+		r3 := v.radius * math.Sin(phi)
+		x, y = math.Atan(r2/r1), math.Asin(r3/math.Sqrt(r1*r1+r2*r2+r3*r3))
+	}
+	return x*v.cos + y*v.sin, -x*v.sin + y*v.cos, true
+}
+
+func (v *spaceView) inverse(x, y float64) (float64, float64, bool) {
+	x, y = x*v.cos-y*v.sin, x*v.sin+y*v.cos
+	var px, py, pz float64
+	if v.distance == 0 {
+		py, pz = x, y
+		rest := v.radius*v.radius - py*py - pz*pz
+		if rest < 0 {
+			return 0, 0, false
+		}
+		px = math.Sqrt(rest)
+	} else {
+		// Пересечение луча обзора со сферой
+		ux, uy, uz := -math.Cos(y)*math.Cos(x), math.Cos(y)*math.Sin(x), math.Sin(y)
+		b := v.distance * ux
+		discriminant := b*b - (v.distance*v.distance - v.radius*v.radius)
+		if discriminant < 0 {
+			return 0, 0, false
+		}
+		t := -b - math.Sqrt(discriminant)
+		px, py, pz = v.distance+t*ux, t*uy, t*uz
+	}
+	return degrees(math.Asin(pz / v.radius)), v.lop + degrees(math.Atan2(py, px)), true
+}
+
+// earthRadius Возвращает радиус Земли в метрах по форме Земли (Code table 3.2).
+// Для эллипсоидов возвращается большая полуось
+func earthRadius(h GridHeader) float64 {
+	switch h.EarthShape {
+	case 0:
+		return 6367470
+	case 1:
+		if radius, ok := h.SphericalRadius.Float(); ok && radius > 0 {
+			return radius
+		}
+	case 2:
+		return 6378160
+	case 3:
+		if axis, ok := h.MajorAxis.Float(); ok && axis > 0 {
+			return axis * 1000
+		}
+	case 4, 5:
+		return 6378137
+	case 7:
+		if axis, ok := h.MajorAxis.Float(); ok && axis > 0 {
+			return axis
+		}
+	case 8:
+		return 6371200
+	}
+	return 6371229
+}
+
+// angleUnit Возвращает цену единицы углов сетки в градусах: basicAngle/subdivisions или 10^-6
+func angleUnit(angle BasicAngle) float64 {
+	if angle.BasicAngle == 0 || angle.BasicAngle == math.MaxUint32 || angle.BasicAngleSub == 0 || angle.BasicAngleSub == math.MaxUint32 {
+		return 1e-6
+	}
+	return float64(angle.BasicAngle) / float64(angle.BasicAngleSub)
+}
+
+// microdegrees Переводит угол из миллионных долей градуса в градусы
+func microdegrees(value int32) float64 {
+	return float64(value) * 1e-6
+}
+
+// normalizeLongitude Приводит долготу к диапазону [-180, 180)
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,394 @@
+package grib2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// ring Замкнутый контур многоугольника, точки — пары долгота, широта в градусах
+type ring [][2]float64
+
+// Region Область, в которой сохраняются данные: прямоугольник по широте и долготе или многоугольники
+// GeoJSON. Для многоугольников West, South, East, North — их охватывающий прямоугольник
+type Region struct {
+	West, South, East, North float64
+	// Многоугольники, каждый из внешнего контура и контуров дыр. Пусто для прямоугольника
+	Polygons [][]ring
+
+	// masks Кэш масок узлов по значению определения сетки
+	masks sync.Map
+}
+
+// NewBBox Создает прямоугольную область. Если east меньше west, область пересекает 180-й меридиан
+func NewBBox(west, south, east, north float64) (*Region, error) {
+	if south < -90 || north > 90 || north < south {
+		return nil, fmt.Errorf("некорректные границы области по широте: %g..%g", south, north)
+	}
+	if east < west {
+		east += 360
+	}
+	if east-west > 360 {
+		return nil, fmt.Errorf("некорректные границы области по долготе: %g..%g", west, east)
+	}
+	return &Region{West: west, South: south, East: east, North: north}, nil
+}
+
+// ParseRegion Разбирает описание области: прямоугольник "запад,юг,восток,север" в градусах
+// или путь к файлу GeoJSON с многоугольниками
+func ParseRegion(spec string) (*Region, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) == 4 {
+		values := make([]float64, 4)
+		var err error
+		for k, part := range parts {
+			if values[k], err = strconv.ParseFloat(strings.TrimSpace(part), 64); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			return NewBBox(values[0], values[1], values[2], values[3])
+		}
+	}
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGeoJSON(data)
+}
+
+// geoJSON Объект GeoJSON: коллекция объектов, объект или геометрия
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Features    []geoJSON       `json:"features"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Geometries  []geoJSON       `json:"geometries"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ParseGeoJSON Создает область из многоугольников GeoJSON: Polygon и MultiPolygon, в том числе
+// внутри Feature, FeatureCollection и GeometryCollection. Остальные геометрии не учитываются
+func ParseGeoJSON(data []byte) (*Region, error) {
+	var object geoJSON
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("некорректный GeoJSON: %w", err)
+	}
+	region := &Region{}
+	if err := region.addGeoJSON(object); err != nil {
+		return nil, err
+	}
+	if len(region.Polygons) == 0 {
+		return nil, fmt.Errorf("в GeoJSON нет многоугольников")
+	}
+	region.West, region.South, region.East, region.North = math.Inf(1), 90, math.Inf(-1), -90
+	for _, polygon := range region.Polygons {
+		for _, point := range polygon[0] {
+			region.West, region.East = math.Min(region.West, point[0]), math.Max(region.East, point[0])
+			region.South, region.North = math.Min(region.South, point[1]), math.Max(region.North, point[1])
+		}
+	}
+	return region, nil
+}
+
+// addGeoJSON Добавляет многоугольники объекта GeoJSON
+func (r *Region) addGeoJSON(object geoJSON) error {
+	switch object.Type {
+	case "FeatureCollection":
+		for _, feature := range object.Features {
+			if err := r.addGeoJSON(feature); err != nil {
+				return err
+			}
+		}
+	case "Feature":
+		if object.Geometry != nil {
+			return r.addGeoJSON(*object.Geometry)
+		}
+	case "GeometryCollection":
+		for _, geometry := range object.Geometries {
+			if err := r.addGeoJSON(geometry); err != nil {
+				return err
+			}
+		}
+	case "Polygon":
+		var polygon []ring
+		if err := json.Unmarshal(object.Coordinates, &polygon); err != nil {
+			return fmt.Errorf("некорректные координаты Polygon: %w", err)
+		}
+		return r.addPolygon(polygon)
+	case "MultiPolygon":
+		var polygons [][]ring
+		if err := json.Unmarshal(object.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("некорректные координаты MultiPolygon: %w", err)
+		}
+		for _, polygon := range polygons {
+			if err := r.addPolygon(polygon); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addPolygon Добавляет многоугольник, проверяя его контуры
+func (r *Region) addPolygon(polygon []ring) error {
+	if len(polygon) == 0 {
+		return nil
+	}
+	for _, contour := range polygon {
+		if len(contour) < 3 {
+			return fmt.Errorf("контур многоугольника из %d точек", len(contour))
+		}
+		for _, point := range contour {
+			if point[1] < -90 || point[1] > 90 {
+				return fmt.Errorf("некорректная широта %g в многоугольнике", point[1])
+			}
+		}
+	}
+	r.Polygons = append(r.Polygons, polygon)
+	return nil
+}
+
+// Contains Сообщает, что точка lat, lon лежит в области. Долгота может быть задана в любом диапазоне
+func (r *Region) Contains(lat, lon float64) bool {
+	if lat < r.South || lat > r.North {
+		return false
+	}
+	// Долгота приводится к диапазону, начинающемуся с западной границы области
+	lon = r.West + math.Mod(math.Mod(lon-r.West, 360)+360, 360)
+	if lon > r.East {
+		return false
+	}
+	if len(r.Polygons) == 0 {
+		return true
+	}
+	for _, polygon := range r.Polygons {
+		// Правило четности: точка в дыре пересекает контуры четное число раз
+		inside := false
+		for _, contour := range polygon {
+			if contour.crossings(lat, lon)%2 == 1 {
+				inside = !inside
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+// crossings Возвращает количество пересечений контура лучом из точки на восток
+func (c ring) crossings(lat, lon float64) int {
+	count := 0
+	for k := range c {
+		a, b := c[k], c[(k+1)%len(c)]
+		if (a[1] > lat) != (b[1] > lat) {
+			if x := a[0] + (lat-a[1])*(b[0]-a[0])/(b[1]-a[1]); x > lon {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// mask Возвращает признаки попадания узлов сетки в область в порядке значений поля
+func (r *Region) mask(projection Projection, definition interface{}) []bool {
+	key, _ := gridKey(definition)
+	if cached, ok := r.masks.Load(key); ok {
+		return cached.([]bool)
+	}
+	ni, nj := projection.Size()
+	inside := make([]bool, ni*nj)
+	for j := 0; j < nj; j++ {
+		for i := 0; i < ni; i++ {
+			lat, lon := projection.LatLon(float64(i), float64(j))
+			inside[projection.Offset(i, j)] = !math.IsNaN(lat) && r.Contains(lat, lon)
+		}
+	}
+	r.masks.Store(key, inside)
+	return inside
+}
+
+// subgrid Возвращает первый столбец, количество столбцов, первую строку и количество строк
+// широтно-долготной сетки, узлы которых попадают в прямоугольник области. На замкнутой по долготе
+// сетке столбцы могут продолжаться через конец строки
+func (r *Region) subgrid(p *latLonProjection) (int, int, int, int, bool) {
+	columns := make([]bool, p.ni)
+	found := false
+	for i := range columns {
+		_, lon := p.LatLon(float64(i), 0)
+		columns[i] = r.West+math.Mod(math.Mod(lon-r.West, 360)+360, 360) <= r.East
+		found = found || columns[i]
+	}
+	j0, count := -1, 0
+	for j := 0; j < p.nj; j++ {
+		lat, _ := p.LatLon(0, float64(j))
+		if lat >= r.South && lat <= r.North {
+			if j0 < 0 {
+				j0 = j
+			}
+			count++
+		}
+	}
+	if !found || count == 0 {
+		return 0, 0, 0, 0, false
+	}
+	// Отрезок столбцов начинается со столбца в области, предыдущий (с учетом замыкания) вне ее.
+	// Если в области все столбцы замкнутой сетки, отрезок начинается с первого
+	i0 := 0
+	for i := range columns {
+		previous := i - 1
+		if p.periodic {
+			previous = (i - 1 + p.ni) % p.ni
+		}
+		if columns[i] && (previous < 0 || !columns[previous]) {
+			i0 = i
+			break
+		}
+	}
+	width := 0
+	for width < p.ni && (p.periodic || i0+width < p.ni) && columns[(i0+width)%p.ni] {
+		width++
+	}
+	return i0, width, j0, count, true
+}
+
+// Crop Возвращает поле, ограниченное областью region. Широтно-долготные и Гауссовы сетки обрезаются
+// по прямоугольнику области с пересчетом La1, Lo1, La2, Lo2, Ni, Nj; узлы вне многоугольников
+// получают пропуски. На сетках в проекциях пропуски получают узлы вне области, сетка не меняется.
+// Упакованные коды сохраняются. Для поля целиком вне области возвращает ErrOutsideGrid
+func (t *Table) Crop(region *Region) (*Table, error) {
+	definition := t.Section3.Sec3.Definition
+	projection, err := NewProjection(definition)
+	if err != nil {
+		return nil, err
+	}
+	ni, nj := projection.Size()
+	if len(t.Data) != ni*nj {
+		return nil, fmt.Errorf("количество значений %d не совпадает с размером сетки %dx%d", len(t.Data), ni, nj)
+	}
+	field := *t
+	codes := len(t.Data_int) == len(t.Data)
+	if grid, ok := projection.(*latLonProjection); ok {
+		i0, width, j0, height, ok := region.subgrid(grid)
+		if !ok {
+			return nil, fmt.Errorf("%w: поле целиком вне области", ErrOutsideGrid)
+		}
+		cropped := scan{ni: width, nj: height, mode: grid.mode}
+		field.Data = make(Values, width*height)
+		if codes {
+			field.Data_int = make([]int32, width*height)
+		}
+		for j := 0; j < height; j++ {
+			for i := 0; i < width; i++ {
+				from, to := grid.Offset((i0+i)%ni, j0+j), cropped.Offset(i, j)
+				field.Data[to] = t.Data[from]
+				if codes {
+					field.Data_int[to] = t.Data_int[from]
+				}
+			}
+		}
+		first, last := [2]int{i0, j0}, [2]int{i0 + width - 1, j0 + height - 1}
+		field.Section3.Sec3.Definition, err = cropDefinition(definition, grid, first, last)
+		if err != nil {
+			return nil, err
+		}
+		field.Section3.Sec3.DataPointCount = uint32(width * height)
+		projection, err = NewProjection(field.Section3.Sec3.Definition)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		field.Data = append(Values(nil), t.Data...)
+		if codes {
+			field.Data_int = append([]int32(nil), t.Data_int...)
+		}
+	}
+	if len(region.Polygons) > 0 || !isLatLon(projection) {
+		inside := region.mask(projection, field.Section3.Sec3.Definition)
+		found := false
+		for k, in := range inside {
+			if in {
+				found = true
+				continue
+			}
+			field.Data[k] = MissingValue
+			if codes {
+				field.Data_int[k] = MissingCode
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: поле целиком вне области", ErrOutsideGrid)
+		}
+	}
+	field.MissingCount = 0
+	for _, value := range field.Data {
+		if IsMissing(value) {
+			field.MissingCount++
+		}
+	}
+	field.Legend = t.Parameter.Legend(t.tables(), field.Data)
+	field.Statistics = ComputeStatistics(field.Data, Percentiles)
+	return &field, nil
+}
+
+// isLatLon Сообщает, что сетка широтно-долготная или Гауссова
+func isLatLon(projection Projection) bool {
+	_, ok := projection.(*latLonProjection)
+	return ok
+}
+
+// cropDefinition Возвращает определение сетки из узлов first..last исходной сетки
+func cropDefinition(definition interface{}, grid *latLonProjection, first, last [2]int) (interface{}, error) {
+	la1, lo1 := grid.LatLon(float64(first[0]), float64(first[1]))
+	la2, lo2 := grid.LatLon(float64(last[0]), float64(last[1]))
+	ni, nj := uint32(last[0]-first[0]+1), uint32(last[1]-first[1]+1)
+	switch g := definition.(type) {
+	case *Grid0:
+		cropped := *g
+		unit := angleUnit(g.BasicAngle)
+		cropped.Ni, cropped.Nj = ni, nj
+		cropped.La1, cropped.Lo1 = toAngle(la1, unit), toAngle(positiveLongitude(lo1), unit)
+		cropped.La2, cropped.Lo2 = toAngle(la2, unit), toAngle(positiveLongitude(lo2), unit)
+		return &cropped, nil
+	case *Grid40:
+		cropped := *g
+		unit := angleUnit(g.BasicAngle)
+		cropped.Ni, cropped.Nj = ni, nj
+		cropped.La1, cropped.Lo1 = toAngle(la1, unit), toAngle(positiveLongitude(lo1), unit)
+		cropped.La2, cropped.Lo2 = toAngle(la2, unit), toAngle(positiveLongitude(lo2), unit)
+		return &cropped, nil
+	}
+	return nil, fmt.Errorf("%w: обрезка сетки %T", ErrUnsupportedTemplate, definition)
+}
+
+// toAngle Переводит градусы в единицы углов сетки
+func toAngle(value float64, unit float64) int32 {
+	return int32(math.Round(value / unit))
+}
+
+// positiveLongitude Приводит долготу к диапазону [0, 360)
+func positiveLongitude(lon float64) float64 {
+	return math.Mod(math.Mod(lon, 360)+360, 360)
+}
+
+// RegionStage Этап ограничения полей областью Region
+type RegionStage struct {
+	immediate
+	Region *Region
+}
+
+// Process Ограничивает поле областью. Поля целиком вне области отбрасываются
+func (s *RegionStage) Process(field *Table) []*Table {
+	cropped, err := field.Crop(s.Region)
+	if err != nil {
+		config.Logger.WithError(err).WithField("parameter", field.Parameter.ShortName).WithField("level", field.SurfaceType+" "+field.SurfaceValue).Warn("Поле не ограничено областью и пропущено")
+		return nil
+	}
+	return []*Table{cropped}
+}
@@ -0,0 +1,235 @@
+package grib2
+
+import (
+	"errors"
+	"testing"
+)
+
+// latLonGrid Возвращает широтно-долготную сетку NewGrid0 с режимом сканирования mode
+func latLonGrid(t *testing.T, west, south, east, north, di, dj float64, mode uint8) *Grid0 {
+	t.Helper()
+	grid, err := NewGrid0(west, south, east, north, di, dj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode&scanNegativeI != 0 {
+		grid.Lo1, grid.Lo2 = grid.Lo2, grid.Lo1
+	}
+	grid.ScanningMode = mode
+	return grid
+}
+
+// checkCodes Проверяет, что упакованные коды поля соответствуют значениям: при нулевых опорном
+// значении и масштабных множителях код равен значению, пропуску соответствует MissingCode
+func checkCodes(t *testing.T, field *Table) {
+	t.Helper()
+	if len(field.Data_int) != len(field.Data) {
+		t.Fatalf("%d кодов для %d значений", len(field.Data_int), len(field.Data))
+	}
+	for k, value := range field.Data {
+		if IsMissing(value) && field.Data_int[k] != MissingCode || !IsMissing(value) && field.Data_int[k] != int32(value) {
+			t.Fatalf("точка %d: код %d, значение %g", k, field.Data_int[k], value)
+		}
+	}
+}
+
+func TestCropLatLon(t *testing.T) {
+	tests := []struct {
+		name   string
+		grid   *Grid0
+		codes  []uint64
+		region [4]float64 // запад, юг, восток, север
+		want   Values
+		// Ожидаемые Ni, Nj и La1, Lo1, La2, Lo2 в микроградусах
+		ni, nj             uint32
+		la1, lo1, la2, lo2 int32
+	}{
+		{
+			"прямоугольник внутри сетки",
+			latLonGrid(t, 0, 58, 3, 60, 1, 1, 0),
+			[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			[4]float64{0.5, 58.5, 2.5, 60},
+			Values{1, 2, 5, 6},
+			2, 2, 60000000, 1000000, 59000000, 2000000,
+		},
+		{
+			"область через 180-й меридиан",
+			latLonGrid(t, 170, 0, -170, 1, 5, 1, 0),
+			[]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			[4]float64{172, 0, -172, 1},
+			Values{1, 2, 3, 6, 7, 8},
+			3, 2, 1000000, 175000000, 0, 185000000,
+		},
+		{
+			"замкнутая сетка через нулевой столбец",
+			latLonGrid(t, 0, 0, 360, 0, 90, 1, 0),
+			[]uint64{0, 1, 2, 3},
+			[4]float64{-100, -10, 100, 10},
+			Values{3, 0, 1},
+			3, 1, 0, 270000000, 0, 90000000,
+		},
+		{
+			"сканирование с востока на запад",
+			latLonGrid(t, 0, 59, 3, 60, 1, 1, scanNegativeI),
+			[]uint64{0, 1, 2, 3, 4, 5, 6, 7},
+			[4]float64{0.5, 59, 2.5, 59.5},
+			Values{5, 6},
+			2, 1, 59000000, 2000000, 59000000, 1000000,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ni, nj := test.grid.Ni, test.grid.Nj
+			field := testField{ni: ni, nj: nj, codes: test.codes, definition: test.grid}.table(t)
+			region, err := NewBBox(test.region[0], test.region[1], test.region[2], test.region[3])
+			if err != nil {
+				t.Fatal(err)
+			}
+			cropped, err := field.Crop(region)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameValues(cropped.Data, test.want) {
+				t.Fatalf("значения %v, ожидались %v", cropped.Data, test.want)
+			}
+			checkCodes(t, cropped)
+			grid := cropped.Section3.Sec3.Definition.(*Grid0)
+			if grid.Ni != test.ni || grid.Nj != test.nj || grid.La1 != test.la1 || grid.Lo1 != test.lo1 || grid.La2 != test.la2 || grid.Lo2 != test.lo2 {
+				t.Fatalf("сетка %dx%d от %d, %d до %d, %d, ожидалась %dx%d от %d, %d до %d, %d", grid.Ni, grid.Nj, grid.La1, grid.Lo1, grid.La2, grid.Lo2,
+					test.ni, test.nj, test.la1, test.lo1, test.la2, test.lo2)
+			}
+			if cropped.Section3.Sec3.DataPointCount != test.ni*test.nj || grid.ScanningMode != test.grid.ScanningMode {
+				t.Fatalf("количество точек %d, режим сканирования 0x%02x", cropped.Section3.Sec3.DataPointCount, grid.ScanningMode)
+			}
+			if grid := field.Section3.Sec3.Definition.(*Grid0); grid.Ni != ni || grid.Nj != nj {
+				t.Fatal("изменена сетка исходного поля")
+			}
+		})
+	}
+}
+
+func TestRegionPolygon(t *testing.T) {
+	region, err := ParseGeoJSON([]byte(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [
+			[[-1, -1], [11, -1], [11, 11], [-1, 11], [-1, -1]],
+			[[4, 4], [6, 4], [6, 6], [4, 6], [4, 4]]
+		]}},
+		{"type": "Feature", "geometry": {"type": "MultiPolygon", "coordinates": [
+			[[[20, 0], [21, 0], [21, 1], [20, 1], [20, 0]]]
+		]}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [50, 50]}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region.West != -1 || region.South != -1 || region.East != 21 || region.North != 11 || len(region.Polygons) != 2 {
+		t.Fatalf("область %g..%g, %g..%g из %d многоугольников", region.West, region.East, region.South, region.North, len(region.Polygons))
+	}
+	tests := []struct {
+		name     string
+		lat, lon float64
+		inside   bool
+	}{
+		{"внутри внешнего контура", 2, 2, true},
+		{"в дыре", 5, 5, false},
+		{"во втором многоугольнике", 0.5, 20.5, true},
+		{"между многоугольниками", 5, 15, false},
+		{"долгота больше 360", 2, 362, true},
+		{"вне охватывающего прямоугольника", 20, 5, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if inside := region.Contains(test.lat, test.lon); inside != test.inside {
+				t.Fatalf("точка в области %v, ожидалось %v", inside, test.inside)
+			}
+		})
+	}
+
+	// Сетка 3x3 с шагом 5°: узел 5° с. ш., 5° в. д. в дыре получает пропуск, сетка обрезается
+	// по охватывающему прямоугольнику
+	field := testField{ni: 3, nj: 3, codes: []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8}, definition: latLonGrid(t, 0, 0, 10, 10, 5, 5, 0)}.table(t)
+	cropped, err := field.Crop(region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Values{0, 1, 2, 3, MissingValue, 5, 6, 7, 8}); !sameValues(cropped.Data, want) || cropped.MissingCount != 1 {
+		t.Fatalf("значения %v (%d пропусков), ожидались %v", cropped.Data, cropped.MissingCount, want)
+	}
+	checkCodes(t, cropped)
+
+	for _, polygon := range []string{
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 1]]]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 91], [2, 0], [0, 0]]]}`,
+		`{"type": "Point", "coordinates": [0, 0]}`,
+		`{"type": "Polygon"`,
+	} {
+		if _, err := ParseGeoJSON([]byte(polygon)); err == nil {
+			t.Fatalf("разобран некорректный GeoJSON %s", polygon)
+		}
+	}
+}
+
+func TestCropProjected(t *testing.T) {
+	grid := lambertGrid(0x30)
+	field := testField{ni: 3, nj: 2, codes: []uint64{0, 1, 2, 3, 4, 5}, definition: grid}.table(t)
+	projection, err := NewProjection(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Первый столбец лежит на центральном меридиане 10° в. д., остальные восточнее
+	region, err := NewBBox(9.9, 40, 10.05, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cropped, err := field.Crop(region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cropped.Section3.Sec3.Definition != grid || len(cropped.Data) != 6 {
+		t.Fatal("сетка в проекции изменена")
+	}
+	for j := 0; j < 2; j++ {
+		for i := 0; i < 3; i++ {
+			k := projection.Offset(i, j)
+			lat, lon := projection.LatLon(float64(i), float64(j))
+			if inside := region.Contains(lat, lon); inside != (i == 0) || inside == IsMissing(cropped.Data[k]) {
+				t.Fatalf("узел %d, %d (%g, %g): значение %g, в области %v", i, j, lat, lon, cropped.Data[k], inside)
+			}
+		}
+	}
+	if cropped.MissingCount != 4 {
+		t.Fatalf("%d пропусков, ожидалось 4", cropped.MissingCount)
+	}
+	checkCodes(t, cropped)
+	if !sameValues(field.Data, Values{0, 1, 2, 3, 4, 5}) {
+		t.Fatalf("изменены значения исходного поля: %v", field.Data)
+	}
+}
+
+func TestCropOutside(t *testing.T) {
+	far, err := NewBBox(100, -10, 110, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		field *Table
+	}{
+		{"широтно-долготная сетка", testField{ni: 3, nj: 2}.table(t)},
+		{"сетка в проекции", testField{ni: 3, nj: 2, definition: lambertGrid(0x30)}.table(t)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.field.Crop(far); !errors.Is(err, ErrOutsideGrid) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, ErrOutsideGrid)
+			}
+			stage := &RegionStage{Region: far}
+			if fields := stage.Process(test.field); len(fields) != 0 {
+				t.Fatalf("поле вне области передано дальше: %d полей", len(fields))
+			}
+		})
+	}
+	if _, err := NewBBox(0, 10, 10, 0); err == nil {
+		t.Fatal("создана область с северной границей южнее южной")
+	}
+}
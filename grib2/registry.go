@@ -0,0 +1,435 @@
+package grib2
+
+import (
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// bundledTables Кодовые таблицы, встроенные в программу.
+// Раскладка каталогов:
+//
+//	wmo/<версия мастер-таблиц>/<таблица>.csv|json
+//	local/<код центра>/<версия локальных таблиц>/<таблица>.csv|json
+//
+// Встроенные мастер-таблицы — один снимок современных таблиц в wmo/0 без привязки к версии ВМО,
+// он применяется к сообщениям любой версии. Таблицы других версий добавляются через LoadTables.
+// Таблица 4.1 содержит колонки discipline, category, description, таблица 4.2 — discipline, category,
+// number, name, unit, short_name, standard_name, code_table, eccodes_name, остальные — code, description.
+// Код может быть диапазоном вида "192-254". В json-файле лежит массив объектов с теми же ключами.
+// Файл units.csv|json в корне содержит пересчеты единиц с колонками unit, target, scale, offset
+//
+//go:embed tables
+var bundledTables embed.FS
+
+// codeRange Описание кода или диапазона кодов
+type codeRange struct {
+	From        int
+	To          int
+	Description string
+}
+
+// codeTable Кодовая таблица: одиночные коды и диапазоны
+type codeTable struct {
+	exact  map[int]string
+	ranges []codeRange
+}
+
+// lookup Ищет описание кода, одиночные коды имеют приоритет перед диапазонами
+func (t *codeTable) lookup(value int) (string, bool) {
+	if description, ok := t.exact[value]; ok {
+		return description, true
+	}
+	for i := len(t.ranges) - 1; i >= 0; i-- {
+		if value >= t.ranges[i].From && value <= t.ranges[i].To {
+			return t.ranges[i].Description, true
+		}
+	}
+	return "", false
+}
+
+// categoryKey Ключ категории параметров: дисциплина и категория
+type categoryKey struct {
+	Discipline uint8
+	Category   uint8
+}
+
+// parameterKey Ключ параметра: дисциплина, категория и номер
+type parameterKey struct {
+	Discipline uint8
+	Category   uint8
+	Number     uint8
+}
+
+// tableLayer Таблицы одной версии: мастер-таблицы ВМО или локальные таблицы центра
+type tableLayer struct {
+	codes      map[string]*codeTable
+	categories map[categoryKey]string
+	parameters map[parameterKey]Parameter
+}
+
+func newTableLayer() *tableLayer {
+	return &tableLayer{
+		codes:      map[string]*codeTable{},
+		categories: map[categoryKey]string{},
+		parameters: map[parameterKey]Parameter{},
+	}
+}
+
+// tableSource Все таблицы из одного источника: встроенные или загруженные из каталога
+type tableSource struct {
+	wmo   map[int]*tableLayer
+	local map[int]map[int]*tableLayer
+	units map[unitKey]UnitConversion
+}
+
+// tableSetKey Версии таблиц из Секции 1, определяющие набор слоев
+type tableSetKey struct {
+	Master uint8
+	Local  uint8
+	Centre uint16
+}
+
+// TableRegistry Реестр кодовых таблиц. Описание кода ищется в слоях, выбранных по версиям мастер-таблиц
+// и локальных таблиц центра из Секции 1 сообщения. Встроенный слой wmo/0 подходит для любой версии,
+// поэтому выбор по версии мастер-таблиц действует только для таблиц, добавленных в реестр отдельно
+type TableRegistry struct {
+	sources []*tableSource
+	mu      sync.Mutex
+	sets    map[tableSetKey][]*tableLayer
+}
+
+// Tables Реестр, которым пользуются функции описания кодов. Изначально содержит встроенные таблицы
+var Tables = mustTables()
+
+func mustTables() *TableRegistry {
+	tables, err := fs.Sub(bundledTables, "tables")
+	if err != nil {
+		panic(err)
+	}
+	registry, err := NewTableRegistry(tables)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// NewTableRegistry Создает реестр из файловых систем с таблицами. Каждая следующая файловая
+// система дополняет и переопределяет записи предыдущих
+func NewTableRegistry(files ...fs.FS) (*TableRegistry, error) {
+	registry := &TableRegistry{sets: map[tableSetKey][]*tableLayer{}}
+	for _, fsys := range files {
+		if err := registry.Add(fsys); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// LoadTables Добавляет в реестр Tables таблицы из каталога dir поверх встроенных
+func LoadTables(dir string) error {
+	return Tables.Add(os.DirFS(dir))
+}
+
+// Add Читает таблицы из файловой системы и добавляет их поверх уже загруженных
+func (r *TableRegistry) Add(fsys fs.FS) error {
+	source := &tableSource{wmo: map[int]*tableLayer{}, local: map[int]map[int]*tableLayer{}, units: map[unitKey]UnitConversion{}}
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := path.Ext(name)
+		if entry.IsDir() || (ext != ".csv" && ext != ".json") {
+			return nil
+		}
+		if strings.TrimSuffix(name, ext) == "units" {
+			rows, err := readTableRows(fsys, name)
+			if err != nil {
+				return fmt.Errorf("таблица %s: %w", name, err)
+			}
+			if err := source.addUnits(rows); err != nil {
+				return fmt.Errorf("таблица %s: %w", name, err)
+			}
+			return nil
+		}
+		layer, err := source.layer(name)
+		if err != nil {
+			return err
+		}
+		if layer == nil {
+			return nil
+		}
+		rows, err := readTableRows(fsys, name)
+		if err != nil {
+			return fmt.Errorf("таблица %s: %w", name, err)
+		}
+		if err := layer.add(strings.TrimSuffix(path.Base(name), ext), rows); err != nil {
+			return fmt.Errorf("таблица %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, source)
+	r.sets = map[tableSetKey][]*tableLayer{}
+	return nil
+}
+
+// layer Возвращает слой, к которому относится файл name. Файлы вне wmo и local, кроме units, пропускаются
+func (s *tableSource) layer(name string) (*tableLayer, error) {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "wmo":
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("таблица %s: неверная версия мастер-таблиц %q", name, parts[1])
+		}
+		if s.wmo[version] == nil {
+			s.wmo[version] = newTableLayer()
+		}
+		return s.wmo[version], nil
+	case len(parts) == 4 && parts[0] == "local":
+		centre, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("таблица %s: неверный код центра %q", name, parts[1])
+		}
+		version, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("таблица %s: неверная версия локальных таблиц %q", name, parts[2])
+		}
+		if s.local[centre] == nil {
+			s.local[centre] = map[int]*tableLayer{}
+		}
+		if s.local[centre][version] == nil {
+			s.local[centre][version] = newTableLayer()
+		}
+		return s.local[centre][version], nil
+	}
+	return nil, nil
+}
+
+// readTableRows Читает строки таблицы из csv- или json-файла в виде словарей колонка-значение
+func readTableRows(fsys fs.FS, name string) ([]map[string]string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if path.Ext(name) == ".json" {
+		var objects []map[string]json.RawMessage
+		if err := json.NewDecoder(file).Decode(&objects); err != nil {
+			return nil, err
+		}
+		rows := make([]map[string]string, len(objects))
+		for i, object := range objects {
+			rows[i] = map[string]string{}
+			for key, raw := range object {
+				var text string
+				if err := json.Unmarshal(raw, &text); err != nil {
+					text = string(raw)
+				}
+				rows[i][key] = text
+			}
+		}
+		return rows, nil
+	}
+	csvReader := csv.NewReader(file)
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]string
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := map[string]string{}
+		for i, key := range header {
+			row[strings.TrimSpace(key)] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+}
+
+// add Добавляет строки таблицы table в слой
+func (l *tableLayer) add(table string, rows []map[string]string) error {
+	for i, row := range rows {
+		var err error
+		switch table {
+		case "4.1":
+			err = l.addCategory(row)
+		case "4.2":
+			err = l.addParameter(row)
+		default:
+			err = l.addCode(table, row)
+		}
+		if err != nil {
+			return fmt.Errorf("строка %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (l *tableLayer) addCode(table string, row map[string]string) error {
+	from, to, err := parseCodeRange(row["code"])
+	if err != nil {
+		return err
+	}
+	codes := l.codes[table]
+	if codes == nil {
+		codes = &codeTable{exact: map[int]string{}}
+		l.codes[table] = codes
+	}
+	if from == to {
+		codes.exact[from] = row["description"]
+	} else {
+		codes.ranges = append(codes.ranges, codeRange{From: from, To: to, Description: row["description"]})
+	}
+	return nil
+}
+
+func (l *tableLayer) addCategory(row map[string]string) error {
+	discipline, err := parseUint8("discipline", row["discipline"])
+	if err != nil {
+		return err
+	}
+	category, err := parseUint8("category", row["category"])
+	if err != nil {
+		return err
+	}
+	l.categories[categoryKey{discipline, category}] = row["description"]
+	return nil
+}
+
+func (l *tableLayer) addParameter(row map[string]string) error {
+	var key parameterKey
+	var err error
+	if key.Discipline, err = parseUint8("discipline", row["discipline"]); err != nil {
+		return err
+	}
+	if key.Category, err = parseUint8("category", row["category"]); err != nil {
+		return err
+	}
+	if key.Number, err = parseUint8("number", row["number"]); err != nil {
+		return err
+	}
+	l.parameters[key] = Parameter{
+		Discipline:   key.Discipline,
+		Category:     key.Category,
+		Number:       key.Number,
+		Name:         row["name"],
+		Unit:         row["unit"],
+		ShortName:    row["short_name"],
+		StandardName: row["standard_name"],
+		CodeTable:    row["code_table"],
+		EcCodesName:  row["eccodes_name"],
+	}
+	return nil
+}
+
+// parseCodeRange Разбирает код "12" или диапазон кодов "192-254"
+func parseCodeRange(code string) (int, int, error) {
+	first, last, isRange := strings.Cut(code, "-")
+	from, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, 0, fmt.Errorf("неверный код %q", code)
+	}
+	if !isRange {
+		return from, from, nil
+	}
+	to, err := strconv.Atoi(last)
+	if err != nil || to < from {
+		return 0, 0, fmt.Errorf("неверный диапазон кодов %q", code)
+	}
+	return from, to, nil
+}
+
+func parseUint8(column string, value string) (uint8, error) {
+	number, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("неверное значение %s %q", column, value)
+	}
+	return uint8(number), nil
+}
+
+// layers Возвращает слои таблиц для версий из Секции 1 в порядке применения: для каждого источника
+// мастер-таблицы ВМО версий не выше указанной, затем локальные таблицы центра версий не выше указанной.
+// Версия мастер-таблиц 255 означает все версии, локальные таблицы не используются при версии 0 и 255
+func (r *TableRegistry) layers(section1 Section1) []*tableLayer {
+	key := tableSetKey{Master: section1.MasterTablesVersion, Local: section1.LocalTablesVersion, Centre: section1.OriginatingCenter}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if layers, ok := r.sets[key]; ok {
+		return layers
+	}
+	var layers []*tableLayer
+	for _, source := range r.sources {
+		layers = append(layers, versionLayers(source.wmo, int(key.Master), key.Master == 255)...)
+		if key.Local != 0 && key.Local != 255 {
+			layers = append(layers, versionLayers(source.local[int(key.Centre)], int(key.Local), false)...)
+		}
+	}
+	r.sets[key] = layers
+	return layers
+}
+
+// versionLayers Возвращает слои версий не выше max по возрастанию версии
+func versionLayers(versions map[int]*tableLayer, max int, all bool) []*tableLayer {
+	var layers []*tableLayer
+	for version := 0; version <= 255; version++ {
+		if layer, ok := versions[version]; ok && (all || version <= max) {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+// Code Возвращает описание значения value из кодовой таблицы table, например "4.5"
+func (r *TableRegistry) Code(section1 Section1, table string, value int) (string, bool) {
+	layers := r.layers(section1)
+	for i := len(layers) - 1; i >= 0; i-- {
+		if codes, ok := layers[i].codes[table]; ok {
+			if description, ok := codes.lookup(value); ok {
+				return description, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Category Возвращает описание категории параметров (Code table 4.1)
+func (r *TableRegistry) Category(section1 Section1, discipline uint8, category uint8) (string, bool) {
+	layers := r.layers(section1)
+	for i := len(layers) - 1; i >= 0; i-- {
+		if description, ok := layers[i].categories[categoryKey{discipline, category}]; ok {
+			return description, true
+		}
+	}
+	return "", false
+}
+
+// Parameter Возвращает описание параметра (Code table 4.2)
+func (r *TableRegistry) Parameter(section1 Section1, discipline uint8, category uint8, number uint8) (Parameter, bool) {
+	layers := r.layers(section1)
+	for i := len(layers) - 1; i >= 0; i-- {
+		if parameter, ok := layers[i].parameters[parameterKey{discipline, category, number}]; ok {
+			return parameter, true
+		}
+	}
+	return Parameter{}, false
+}
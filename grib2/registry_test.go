@@ -0,0 +1,64 @@
+package grib2
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		section1   Section1
+		discipline uint8
+		category   uint8
+		number     uint8
+		shortName  string
+		unit       string
+	}{
+		{"ВМО", Section1{OriginatingCenter: 98, MasterTablesVersion: 2}, 0, 0, 0, "TMP", "K"},
+		{"NCEP", Section1{OriginatingCenter: 7, MasterTablesVersion: 2, LocalTablesVersion: 1}, 0, 1, 192, "CRAIN", "Code table 4.222"},
+		{"ECMWF", Section1{OriginatingCenter: 98, MasterTablesVersion: 2, LocalTablesVersion: 1}, 192, 128, 167, "2t", "K"},
+		{"ECMWF, осадки", Section1{OriginatingCenter: 98, MasterTablesVersion: 2, LocalTablesVersion: 1}, 192, 128, 228, "tp", "m"},
+		{"ECMWF без локальных таблиц", Section1{OriginatingCenter: 98, MasterTablesVersion: 2}, 192, 128, 167, "var192_128_167", ""},
+		{"таблицы другого центра", Section1{OriginatingCenter: 7, MasterTablesVersion: 2, LocalTablesVersion: 1}, 192, 128, 167, "var192_128_167", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parameter := LookupParameter(test.section1, test.discipline, test.category, test.number)
+			if parameter.ShortName != test.shortName || parameter.Unit != test.unit {
+				t.Fatalf("%s [%s], ожидалось %s [%s]", parameter.ShortName, parameter.Unit, test.shortName, test.unit)
+			}
+		})
+	}
+	section1 := Section1{OriginatingCenter: 98, MasterTablesVersion: 2, LocalTablesVersion: 1}
+	if description, ok := Tables.Category(section1, 192, 128); !ok || description == "" {
+		t.Fatal("нет описания локальной категории ECMWF")
+	}
+}
+
+func TestMasterVersions(t *testing.T) {
+	registry, err := NewTableRegistry(fstest.MapFS{
+		"wmo/0/4.5.csv":  {Data: []byte("code,description\n1,Ground or water surface\n2,Cloud base level\n")},
+		"wmo/30/4.5.csv": {Data: []byte("code,description\n1,Ground or water surface (v30)\n")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		version     uint8
+		code        int
+		description string
+	}{
+		{2, 1, "Ground or water surface"},
+		{29, 1, "Ground or water surface"},
+		{30, 1, "Ground or water surface (v30)"},
+		{255, 1, "Ground or water surface (v30)"},
+		{30, 2, "Cloud base level"},
+	}
+	for _, test := range tests {
+		description, ok := registry.Code(Section1{MasterTablesVersion: test.version}, "4.5", test.code)
+		if !ok || description != test.description {
+			t.Errorf("версия %d, код %d: %q, ожидалось %q", test.version, test.code, description, test.description)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package grib2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// ErrGridRelative Компоненты векторов заданы относительно осей сетки в проекции и не могут быть
+// переведены на широтно-долготную сетку без поворота
+var ErrGridRelative = errors.New("компоненты векторов заданы относительно осей сетки в проекции")
+
+// regridKey Пара исходной и целевой сеток и способ интерполяции
+type regridKey struct {
+	source interface{}
+	target Grid0
+	method Interpolation
+}
+
+// regridPlan Веса узлов исходной сетки для каждой точки целевой сетки. Точки вне исходной
+// сетки не имеют весов и получают MissingValue
+type regridPlan struct {
+	once   sync.Once
+	points [][]weight
+	size   int
+	err    error
+}
+
+// regridPlans Кэш весов по паре сеток и способу интерполяции
+var regridPlans sync.Map
+
+// NewGrid0 Создает широтно-долготную сетку шаблона 3.0 с шагами di, dj (градусы), строки которой
+// идут с севера на юг, а точки строки — с запада на восток. Если east меньше west, сетка
+// пересекает 180-й меридиан
+func NewGrid0(west, south, east, north, di, dj float64) (*Grid0, error) {
+	if di <= 0 || dj <= 0 {
+		return nil, fmt.Errorf("шаг сетки должен быть положительным: %g, %g", di, dj)
+	}
+	if south < -90 || north > 90 || north < south {
+		return nil, fmt.Errorf("некорректные границы сетки по широте: %g..%g", south, north)
+	}
+	if east < west {
+		east += 360
+	}
+	if east-west > 360 {
+		return nil, fmt.Errorf("некорректные границы сетки по долготе: %g..%g", west, east)
+	}
+	ni := int(math.Round((east-west)/di)) + 1
+	nj := int(math.Round((north-south)/dj)) + 1
+	// Глобальная сетка не повторяет первый меридиан в конце строки
+	if math.Abs(float64(ni-1)*di-360) < di/2 {
+		ni--
+		east -= di
+	}
+	west = math.Mod(west+360, 360)
+	east = math.Mod(east+360, 360)
+	return &Grid0{
+		GridHeader:                  GridHeader{EarthShape: 6},
+		Ni:                          uint32(ni),
+		Nj:                          uint32(nj),
+		La1:                         int32(math.Round(north * 1e6)),
+		Lo1:                         int32(math.Round(west * 1e6)),
+		ResolutionAndComponentFlags: 0x30,
+		La2:                         int32(math.Round(south * 1e6)),
+		Lo2:                         int32(math.Round(east * 1e6)),
+		Di:                          int32(math.Round(di * 1e6)),
+		Dj:                          int32(math.Round(dj * 1e6)),
+	}, nil
+}
+
+// ParseGrid0 Разбирает описание сетки вида "запад,юг,восток,север,шаг" или с отдельным шагом
+// по широте "запад,юг,восток,север,шаг_i,шаг_j", например "-10,35,40,70,0.1"
+func ParseGrid0(spec string) (*Grid0, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) != 5 && len(parts) != 6 {
+		return nil, fmt.Errorf("сетка %q: ожидается запад,юг,восток,север,шаг", spec)
+	}
+	values := make([]float64, len(parts))
+	for k, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("сетка %q: %w", spec, err)
+		}
+		values[k] = value
+	}
+	dj := values[4]
+	if len(values) == 6 {
+		dj = values[5]
+	}
+	return NewGrid0(values[0], values[1], values[2], values[3], values[4], dj)
+}
+
+// Regrid Переводит значения data с сетки section3 на сетку target. Веса узлов вычисляются один раз
+// для пары сеток и способа интерполяции и затем берутся из кэша. Способ Conservative усредняет
+// значения исходной сетки по площади ячеек целевой сетки: для широтно-долготных сеток площади
+// пересечения ячеек вычисляются точно, для остальных проекций — по равномерной выборке точек ячейки.
+// Точки целевой сетки вне исходной получают MissingValue
+func Regrid(section3 Section3, data Values, target *Grid0, method Interpolation) (Values, error) {
+	plan, err := planRegrid(section3.Definition, target, method)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != plan.size {
+		return nil, fmt.Errorf("количество значений %d не совпадает с размером сетки %d", len(data), plan.size)
+	}
+	values := make(Values, len(plan.points))
+	for k, weights := range plan.points {
+		values[k] = apply(weights, data)
+	}
+	return values, nil
+}
+
+// planRegrid Возвращает веса для пары сеток, вычисляя их при первом обращении
+func planRegrid(definition interface{}, target *Grid0, method Interpolation) (*regridPlan, error) {
+	source, ok := gridKey(definition)
+	if !ok {
+		return nil, fmt.Errorf("%w: проекция сетки %T", ErrUnsupportedTemplate, definition)
+	}
+	value, _ := regridPlans.LoadOrStore(regridKey{source: source, target: *target, method: method}, &regridPlan{})
+	plan := value.(*regridPlan)
+	plan.once.Do(func() {
+		plan.points, plan.size, plan.err = computeRegrid(definition, target, method)
+	})
+	return plan, plan.err
+}
+
+// computeRegrid Вычисляет веса узлов исходной сетки для всех точек целевой сетки
+func computeRegrid(definition interface{}, target *Grid0, method Interpolation) ([][]weight, int, error) {
+	source, err := NewProjection(definition)
+	if err != nil {
+		return nil, 0, err
+	}
+	projection, err := NewProjection(target)
+	if err != nil {
+		return nil, 0, err
+	}
+	destination := projection.(*latLonProjection)
+	ni, nj := source.Size()
+	points := make([][]weight, destination.ni*destination.nj)
+	for j := 0; j < destination.nj; j++ {
+		for i := 0; i < destination.ni; i++ {
+			lat, lon := destination.LatLon(float64(i), float64(j))
+			var weights []weight
+			if method == Conservative {
+				halfLat, halfLon := math.Abs(destination.dlat)/2, math.Abs(destination.dlon)/2
+				south, north := math.Max(lat-halfLat, -90), math.Min(lat+halfLat, 90)
+				weights = cellWeights(source, south, north, lon-halfLon, lon+halfLon)
+			} else {
+				weights, err = pointWeights(source, lat, lon, method)
+				if err != nil && !errors.Is(err, ErrOutsideGrid) {
+					return nil, 0, err
+				}
+			}
+			points[destination.Offset(i, j)] = weights
+		}
+	}
+	return points, ni * nj, nil
+}
+
+// cellWeights Возвращает узлы исходной сетки и площади их пересечения с ячейкой south..north, west..east
+func cellWeights(source Projection, south, north, west, east float64) []weight {
+	if grid, ok := source.(*latLonProjection); ok {
+		return grid.overlap(south, north, west, east)
+	}
+	// Количество выборочных точек вдоль стороны ячейки — примерно по две на шаг исходной сетки
+	lat, lon := (south+north)/2, (west+east)/2
+	samples := 2
+	if i, j, err := gridLocate(source, lat, lon); err == nil {
+		ni, nj := source.Size()
+		i0, j0 := min(int(i), ni-2), min(int(j), nj-2)
+		lat0, lon0 := source.LatLon(float64(max(i0, 0)), float64(max(j0, 0)))
+		lat1, lon1 := source.LatLon(float64(max(i0, 0)+1), float64(max(j0, 0)))
+		if step := degrees(angularDistance(lat0, lon0, lat1, lon1)); step > 0 {
+			samples = max(2, min(16, int(math.Ceil(2*(north-south)/step))))
+		}
+	}
+	areas := map[int]float64{}
+	ni, _ := source.Size()
+	for a := 0; a < samples; a++ {
+		sampleLat := south + (float64(a)+0.5)*(north-south)/float64(samples)
+		for b := 0; b < samples; b++ {
+			sampleLon := west + (float64(b)+0.5)*(east-west)/float64(samples)
+			i, j, err := gridLocate(source, sampleLat, sampleLon)
+			if err != nil {
+				continue
+			}
+			ri, rj := int(math.Round(i)), int(math.Round(j))
+			if ri == ni {
+				ri = 0
+			}
+			areas[source.Offset(ri, rj)] += math.Cos(radians(sampleLat))
+		}
+	}
+	weights := make([]weight, 0, len(areas))
+	for offset, area := range areas {
+		weights = append(weights, weight{Offset: offset, Weight: area})
+	}
+	sort.Slice(weights, func(a, b int) bool { return weights[a].Offset < weights[b].Offset })
+	return weights
+}
+
+// overlap Возвращает узлы широтно-долготной сетки и площади пересечения их ячеек с ячейкой
+// south..north, west..east на единичной сфере
+func (p *latLonProjection) overlap(south, north, west, east float64) []weight {
+	var weights []weight
+	// Строки, ячейки которых могут пересекаться с ячейкой
+	_, jSouth, _ := p.Index(south, p.lon1)
+	_, jNorth, _ := p.Index(north, p.lon1)
+	first := max(0, int(math.Floor(math.Min(jSouth, jNorth)))-1)
+	last := min(p.nj-1, int(math.Ceil(math.Max(jSouth, jNorth)))+1)
+	if math.IsInf(jSouth, 0) || math.IsInf(jNorth, 0) {
+		first, last = 0, p.nj-1
+	}
+	for r := first; r <= last; r++ {
+		low, high := p.rowBounds(r)
+		low, high = math.Max(low, south), math.Min(high, north)
+		if high <= low {
+			continue
+		}
+		band := math.Sin(radians(high)) - math.Sin(radians(low))
+		for _, column := range p.columns(west, east) {
+			weights = append(weights, weight{Offset: p.Offset(column.Offset, r), Weight: band * column.Weight})
+		}
+	}
+	return weights
+}
+
+// rowBounds Возвращает границы ячеек строки r по широте: середины между соседними строками.
+// Крайние строки глобальной сетки продолжаются до полюсов
+func (p *latLonProjection) rowBounds(r int) (float64, float64) {
+	if p.nj == 1 {
+		return p.lat1, p.lat1
+	}
+	a, _ := p.LatLon(0, float64(r)-0.5)
+	b, _ := p.LatLon(0, float64(r)+0.5)
+	low, high := math.Min(a, b), math.Max(a, b)
+	if p.periodic {
+		if r == 0 || r == p.nj-1 {
+			edge, _ := p.LatLon(0, float64(r))
+			if 90-math.Abs(edge) <= 180/float64(p.nj)+indexEpsilon {
+				if edge > 0 {
+					high = 90
+				} else {
+					low = -90
+				}
+			}
+		}
+	}
+	return math.Max(low, -90), math.Min(high, 90)
+}
+
+// columns Возвращает столбцы, ячейки которых пересекаются с интервалом долгот west..east, и ширину
+// пересечения в радианах (в поле Weight)
+func (p *latLonProjection) columns(west, east float64) []weight {
+	if p.dlon == 0 {
+		return []weight{{Offset: 0, Weight: radians(east - west)}}
+	}
+	step := math.Abs(p.dlon)
+	// Начало интервала в индексах i: при обратном сканировании индексы растут на запад
+	var start float64
+	if p.dlon > 0 {
+		start = math.Mod(math.Mod(west-p.lon1, 360)+360, 360) / step
+	} else {
+		start = math.Mod(math.Mod(p.lon1-east, 360)+360, 360) / step
+	}
+	width := (east - west) / step
+	shifts := []float64{0}
+	if !p.periodic {
+		// Интервал может начинаться западнее первой точки региональной сетки
+		shifts = append(shifts, -360/step)
+	}
+	var columns []weight
+	for _, shift := range shifts {
+		from, to := start+shift, start+shift+width
+		for c := int(math.Floor(from + 0.5)); c <= int(math.Floor(to+0.5)); c++ {
+			covered := math.Min(to, float64(c)+0.5) - math.Max(from, float64(c)-0.5)
+			if covered <= 0 {
+				continue
+			}
+			column := c
+			if p.periodic {
+				column = (c%p.ni + p.ni) % p.ni
+			} else if c < 0 || c > p.ni-1 {
+				continue
+			}
+			columns = append(columns, weight{Offset: column, Weight: radians(covered * step)})
+		}
+	}
+	return columns
+}
+
+// Regrid Возвращает поле, переведенное на сетку target. Категориальные параметры всегда
+// переводятся по ближайшему узлу, чтобы не появлялись несуществующие коды. Флаг компонент
+// векторов относительно осей широтно-долготной или Гауссовой сетки переносится на новую сетку.
+// Компоненты векторов относительно осей сетки в проекции не переводятся (ErrGridRelative):
+// их нужно сначала повернуть на восток и на север через RotateToEarth
+func (t *Table) Regrid(target *Grid0, method Interpolation) (*Table, error) {
+	if t.Parameter.Categorical() {
+		method = Nearest
+	}
+	flags, _ := componentFlags(t.Section3.Sec3.Definition)
+	relative := flags & gridRelativeFlag
+	if relative != 0 {
+		projection, err := NewProjection(t.Section3.Sec3.Definition)
+		if err != nil {
+			return nil, err
+		}
+		if _, planar := projection.(*planeProjection); planar {
+			if _, _, vector := vectorComponent(t); vector {
+				return nil, fmt.Errorf("%w: %s", ErrGridRelative, t.Parameter.ShortName)
+			}
+			// Оси новой сетки не совпадают с осями проекции, флаг к ней не относится
+			relative = 0
+		}
+	}
+	data, err := Regrid(t.Section3.Sec3, t.Data, target, method)
+	if err != nil {
+		return nil, err
+	}
+	field := t.derive(data)
+	grid := *target
+	grid.ResolutionAndComponentFlags |= relative
+	field.Section3 = S3{
+		Name: GridName(0),
+		Sec3: Section3{
+			DataPointCount: grid.Ni * grid.Nj,
+			TemplateNumber: 0,
+			Definition:     &grid,
+		},
+	}
+	return field, nil
+}
+
+// RegridStage Этап перевода всех полей на общую сетку Target
+type RegridStage struct {
+	immediate
+	Target *Grid0
+	Method Interpolation
+}
+
+// Process Переводит поле на целевую сетку. Поля, которые не удалось перевести, отбрасываются
+func (s *RegridStage) Process(field *Table) []*Table {
+	if grid, ok := field.Section3.Sec3.Definition.(*Grid0); ok && *grid == *s.Target {
+		return []*Table{field}
+	}
+	regridded, err := field.Regrid(s.Target, s.Method)
+	if err != nil {
+		config.Logger.WithError(err).WithField("parameter", field.Parameter.ShortName).WithField("level", field.SurfaceType+" "+field.SurfaceValue).Error("Ошибка перевода поля на общую сетку, поле пропущено")
+		return nil
+	}
+	return []*Table{regridded}
+}
@@ -0,0 +1,113 @@
+package grib2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// regridSource Возвращает поле 3x2: строка 60° с. ш. — 0, 10, 20; строка 59° с. ш. — 30, 40, 50
+func regridSource(t *testing.T) *Table {
+	t.Helper()
+	return testField{ni: 3, nj: 2, codes: []uint64{0, 10, 20, 30, 40, 50}}.table(t)
+}
+
+// lambertGrid Возвращает сетку Ламберта 3x2 с шагом 10 км и флагами flags
+func lambertGrid(flags uint8) *Grid30 {
+	return &Grid30{
+		GridHeader:                  GridHeader{EarthShape: 6},
+		Nx:                          3,
+		Ny:                          2,
+		La1:                         50000000,
+		Lo1:                         10000000,
+		ResolutionAndComponentFlags: flags,
+		Lad:                         50000000,
+		Lov:                         10000000,
+		Dx:                          10000000,
+		Dy:                          10000000,
+		ScanningMode:                0x40,
+		Latin1:                      50000000,
+		Latin2:                      50000000,
+		LaSouthPole:                 -90000000,
+	}
+}
+
+func TestRegrid(t *testing.T) {
+	tests := []struct {
+		name   string
+		target [6]float64 // запад, юг, восток, север, шаг по долготе и по широте
+		method Interpolation
+		want   Values
+	}{
+		{"та же сетка", [6]float64{0, 59, 2, 60, 1, 1}, Bilinear, Values{0, 10, 20, 30, 40, 50}},
+		{"билинейная, шаг 0.5°", [6]float64{0, 59, 1, 60, 0.5, 0.5}, Bilinear, Values{0, 5, 10, 15, 20, 25, 30, 35, 40}},
+		{"ближайший узел", [6]float64{0.6, 59, 1.6, 59, 1, 1}, Nearest, Values{40, 50}},
+		{"точки вне исходной сетки", [6]float64{1, 59, 3, 59, 1, 1}, Bilinear, Values{40, 50, MissingValue}},
+		// Ячейка 59..60° с. ш., 0..1° в. д. пересекается с половинами четырех ячеек исходной сетки,
+		// площади северной и южной половин различаются. Ячейки 58..59° с. ш. пересекаются только
+		// с южной строкой исходной сетки до 58.5° с. ш.
+		{"сохраняющая", [6]float64{0.5, 58.5, 1.5, 59.5, 1, 1}, Conservative, Values{20.111112496474682, 30.111112496474682, 35, 45}},
+	}
+	field := regridSource(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := NewGrid0(test.target[0], test.target[1], test.target[2], test.target[3], test.target[4], test.target[5])
+			if err != nil {
+				t.Fatal(err)
+			}
+			regridded, err := field.Regrid(target, test.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(regridded.Data) != len(test.want) {
+				t.Fatalf("значения %v, ожидались %v", regridded.Data, test.want)
+			}
+			for k := range test.want {
+				if IsMissing(test.want[k]) != IsMissing(regridded.Data[k]) || math.Abs(regridded.Data[k]-test.want[k]) > 1e-9 {
+					t.Fatalf("значения %v, ожидались %v", regridded.Data, test.want)
+				}
+			}
+			if grid := regridded.Section3.Sec3.Definition.(*Grid0); *grid != *target {
+				t.Fatalf("сетка %+v, ожидалась %+v", *grid, *target)
+			}
+		})
+	}
+}
+
+func TestRegridGridRelative(t *testing.T) {
+	target, err := NewGrid0(0, 59, 2, 60, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wind := regridSource(t)
+	wind.Parameter = LookupParameter(defaultSection1, 0, 2, 2)
+	tests := []struct {
+		name       string
+		parameter  Parameter
+		definition interface{}
+		flags      uint8
+		err        error
+	}{
+		{"температура в проекции", regridSource(t).Parameter, lambertGrid(0x38), 0x30, nil},
+		{"ветер на восток и на север в проекции", wind.Parameter, lambertGrid(0x30), 0x30, nil},
+		{"ветер относительно осей проекции", wind.Parameter, lambertGrid(0x38), 0, ErrGridRelative},
+		{"ветер относительно осей широтно-долготной сетки", wind.Parameter, withComponentFlags(wind.Section3.Sec3.Definition, 0x38), 0x38, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := *wind
+			field.Parameter = test.parameter
+			field.Section3.Sec3.Definition = test.definition
+			regridded, err := field.Regrid(target, Nearest)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if flags := regridded.Section3.Sec3.Definition.(*Grid0).ResolutionAndComponentFlags; flags != test.flags {
+				t.Fatalf("флаги 0x%02x, ожидались 0x%02x", flags, test.flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package grib2
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// rawMessage Сырое сообщение, найденное на этапе разбиения файла: Секция 0 и байты остальных секций
+type rawMessage struct {
+	Index  int
+	Offset int64
+	Sec0   Section0
+	Body   []byte
+}
+
+// messageScanner Быстро находит границы сообщений <GRIB ----- 7777> в потоке, не разбирая их содержимое
+type messageScanner struct {
+	file   string
+	reader *bufio.Reader
+	offset int64
+	index  int
+	// Байты поврежденного сообщения после индикатора, которые нужно просмотреть заново при ресинхронизации
+	pending []byte
+	start   int64
+}
+
+// newMessageScanner Создает сканер сообщений поверх буферизированного читателя
+func newMessageScanner(file io.Reader, name string) *messageScanner {
+	return &messageScanner{
+		file:   name,
+		reader: bufio.NewReaderSize(file, 1<<20),
+	}
+}
+
+// skipToIndicator Пропускает байты до индикатора "GRIB" и возвращает смещение его начала
+func (s *messageScanner) skipToIndicator() (int64, error) {
+	var window uint32
+	for {
+		b, err := s.reader.ReadByte()
+		if err != nil {
+			return s.offset, err
+		}
+		s.offset++
+		window = window<<8 | uint32(b)
+		if window == Grib {
+			return s.offset - 4, nil
+		}
+	}
+}
+
+// fail Запоминает прочитанные байты сообщения для возможной ресинхронизации и возвращает ошибку
+func (s *messageScanner) fail(read []byte, offset int64, err error) error {
+	s.pending = read
+	return &MessageError{File: s.file, Message: s.index - 1, Section: 0, Offset: offset, Err: err}
+}
+
+// Resync Возвращает в поток байты последнего поврежденного сообщения, чтобы поиск
+// следующего индикатора "GRIB" начался сразу после индикатора поврежденного сообщения
+func (s *messageScanner) Resync() {
+	if s.pending == nil {
+		return
+	}
+	s.reader = bufio.NewReaderSize(io.MultiReader(bytes.NewReader(s.pending), s.reader), 1<<20)
+	s.offset = s.start + 4
+	s.pending = nil
+}
+
+// Next Возвращает следующее сообщение файла или io.EOF, если сообщений больше нет
+func (s *messageScanner) Next() (*rawMessage, error) {
+	s.pending = nil
+	start, err := s.skipToIndicator()
+	if err != nil {
+		return nil, err
+	}
+	s.start = start
+	s.index++
+	head := make([]byte, 12)
+	numBytes, err := io.ReadFull(s.reader, head)
+	s.offset += int64(numBytes)
+	if err != nil {
+		return nil, s.fail(head[:numBytes], start, fmt.Errorf("%w: Секция 0 прочитана не полностью", ErrTruncatedMessage))
+	}
+	var sec0 Section0
+	binary.Read(bytes.NewReader(head), binary.BigEndian, &sec0)
+	if sec0.Edition != SupportedGribEdition {
+		return nil, s.fail(head, start, fmt.Errorf("%w: версия GRIB %d", ErrUnsupportedTemplate, sec0.Edition))
+	}
+	if sec0.MessageLength < 16+4 {
+		return nil, s.fail(head, start, fmt.Errorf("%w: длина сообщения %d", ErrLengthMismatch, sec0.MessageLength))
+	}
+	if err := checkLimit("длина сообщения", sec0.MessageLength, DecodeLimits.MaxMessageSize); err != nil {
+		return nil, s.fail(head, start, err)
+	}
+	// Память выделяется по мере чтения, поэтому длина из заголовка обрезанного файла не приводит к выделению лишнего
+	length := int64(sec0.MessageLength - 16)
+	body, err := io.ReadAll(io.LimitReader(s.reader, length))
+	s.offset += int64(len(body))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) != length {
+		return nil, s.fail(append(head, body...), s.offset, fmt.Errorf("%w: ожидаемый размер(%v) и прочитанный(%v) не совпадают", ErrTruncatedMessage, length, len(body)))
+	}
+	if !bytes.Equal(body[len(body)-4:], []byte("7777")) {
+		return nil, s.fail(append(head, body...), s.offset-4, fmt.Errorf("%w: %q вместо \"7777\"", ErrBadEndMarker, body[len(body)-4:]))
+	}
+	return &rawMessage{
+		Index:  s.index - 1,
+		Offset: start,
+		Sec0:   sec0,
+		Body:   body,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package grib2
+
+import "math"
+
+// Целые числа со знаком в GRIB2 записываются в прямом коде: старший бит — знак, остальные биты — модуль.
+// Значение из одних единиц означает отсутствие значения (missing) и заменяется на MissingIntN.
+// В прямом коде такие числа не представимы, поэтому их нельзя спутать с настоящими значениями
+const (
+	MissingInt8  int8  = math.MinInt8
+	MissingInt16 int16 = math.MinInt16
+	MissingInt32 int32 = math.MinInt32
+)
+
+// signed8 Переводит 8-битное число, прочитанное как дополнительный код, из прямого кода
+func signed8(v int8) int8 {
+	raw := uint8(v)
+	if raw == math.MaxUint8 {
+		return MissingInt8
+	}
+	if raw&0x80 != 0 {
+		return -int8(raw & 0x7F)
+	}
+	return v
+}
+
+// signed16 Переводит 16-битное число, прочитанное как дополнительный код, из прямого кода
+func signed16(v int16) int16 {
+	raw := uint16(v)
+	if raw == math.MaxUint16 {
+		return MissingInt16
+	}
+	if raw&0x8000 != 0 {
+		return -int16(raw & 0x7FFF)
+	}
+	return v
+}
+
+// signed32 Переводит 32-битное число, прочитанное как дополнительный код, из прямого кода
+func signed32(v int32) int32 {
+	raw := uint32(v)
+	if raw == math.MaxUint32 {
+		return MissingInt32
+	}
+	if raw&0x80000000 != 0 {
+		return -int32(raw & 0x7FFFFFFF)
+	}
+	return v
+}
+
+// scaled Возвращает value * 10^-scale
+func scaled(scale int8, value float64) float64 {
+	return value * math.Pow(10, -float64(scale))
+}
+
+// Float Возвращает значение с учетом масштабного множителя. ok равен false, если значение отсутствует
+func (v ScaledValue) Float() (value float64, ok bool) {
+	if v.Scale == MissingInt8 || v.Value == math.MaxUint32 {
+		return math.NaN(), false
+	}
+	return scaled(v.Scale, float64(v.Value)), true
+}
+
+// Float Возвращает значение поверхности с учетом масштабного множителя. ok равен false, если значение отсутствует
+func (s Surface) Float() (value float64, ok bool) {
+	if s.Scale == MissingInt8 || s.Value == MissingInt32 {
+		return math.NaN(), false
+	}
+	return scaled(s.Scale, float64(s.Value)), true
+}
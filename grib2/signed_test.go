@@ -0,0 +1,103 @@
+package grib2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignMagnitude(t *testing.T) {
+	tests8 := []struct {
+		raw  uint8
+		want int8
+	}{
+		{0x00, 0}, {0x05, 5}, {0x7F, 127}, {0x80, 0}, {0x81, -1}, {0xFE, -126}, {0xFF, MissingInt8},
+	}
+	for _, test := range tests8 {
+		if got := signed8(int8(test.raw)); got != test.want {
+			t.Errorf("signed8(%#x) = %d, ожидалось %d", test.raw, got, test.want)
+		}
+	}
+	tests16 := []struct {
+		raw  uint16
+		want int16
+	}{
+		{0x0003, 3}, {0x8001, -1}, {0x8003, -3}, {0x7FFF, 32767}, {0xFFFF, MissingInt16},
+	}
+	for _, test := range tests16 {
+		if got := signed16(int16(test.raw)); got != test.want {
+			t.Errorf("signed16(%#x) = %d, ожидалось %d", test.raw, got, test.want)
+		}
+	}
+	tests32 := []struct {
+		raw  uint32
+		want int32
+	}{
+		{0x00000064, 100}, {0x80000064, -100}, {0x81312D00, -20000000}, {0xFFFFFFFF, MissingInt32},
+	}
+	for _, test := range tests32 {
+		if got := signed32(int32(test.raw)); got != test.want {
+			t.Errorf("signed32(%#x) = %d, ожидалось %d", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestSurfaceFloat(t *testing.T) {
+	tests := []struct {
+		surface Surface
+		value   float64
+		ok      bool
+	}{
+		{Surface{Type: 100, Scale: 0, Value: 85000}, 85000, true},
+		{Surface{Type: 103, Scale: 1, Value: 25}, 2.5, true},
+		{Surface{Type: 103, Scale: -2, Value: 3}, 300, true},
+		{Surface{Type: 106, Scale: 2, Value: -10}, -0.1, true},
+		{Surface{Type: 1, Scale: MissingInt8, Value: 0}, 0, false},
+		{Surface{Type: 1, Scale: 0, Value: MissingInt32}, 0, false},
+	}
+	for _, test := range tests {
+		value, ok := test.surface.Float()
+		if ok != test.ok || ok && math.Abs(value-test.value) > 1e-12 {
+			t.Errorf("%+v: %v %v, ожидалось %v %v", test.surface, value, ok, test.value, test.ok)
+		}
+	}
+}
+
+func TestSignedScaleFactors(t *testing.T) {
+	tests := []struct {
+		name string
+		e, d uint16
+		want Values
+	}{
+		{"E = 1", 0x0001, 0, Values{250, 252, 254}},
+		{"E = -1", 0x8001, 0, Values{250, 250.5, 251}},
+		{"D = 1", 0, 0x0001, Values{25, 25.1, 25.2}},
+		{"D = -1", 0, 0x8001, Values{2500, 2510, 2520}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, err := decodeBytes(t, simpleMessage(3, 1, []uint64{0, 1, 2}, 8, 250, test.e, test.d, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, value := range message.Section7.Data {
+				if math.Abs(value-test.want[i]) > 1e-9 {
+					t.Fatalf("значения %v, ожидалось %v", message.Section7.Data, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSignedGridCoordinates(t *testing.T) {
+	message, err := decodeBytes(t, simpleMessage(2, 81, make([]uint64, 162), 0, 0, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, ok := message.Section3.Definition.(*Grid0)
+	if !ok {
+		t.Fatalf("сетка %T", message.Section3.Definition)
+	}
+	if grid.La1 != 60000000 || grid.La2 != -20000000 || grid.Lo2 != 1000000 {
+		t.Fatalf("la1 %d, la2 %d, lo2 %d", grid.La1, grid.La2, grid.Lo2)
+	}
+}
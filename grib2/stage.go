@@ -0,0 +1,68 @@
+package grib2
+
+// Stage Этап обработки полей между декодированием и сохранением. Process получает очередное поле
+// и возвращает поля для дальнейшей обработки: само поле, измененное поле, производные поля или
+// ничего, если поле задержано до получения остальных. Flush вызывается после чтения всех файлов
+// и возвращает задержанные и накопленные поля. Этапы вызываются из нескольких горутин
+type Stage interface {
+	Process(field *Table) []*Table
+	Flush() []*Table
+}
+
+// immediate Встраивается в этапы, которые обрабатывают каждое поле сразу и ничего не задерживают
+type immediate struct{}
+
+// Flush Возвращает nil: задержанных полей у этапа нет
+func (immediate) Flush() []*Table {
+	return nil
+}
+
+// Pipeline Последовательность этапов: результаты каждого этапа передаются следующему
+type Pipeline []Stage
+
+// Stages Этапы обработки полей при загрузке, настраиваются в Configure
+var Stages Pipeline
+
+// Process Пропускает поле через все этапы
+func (p Pipeline) Process(field *Table) []*Table {
+	fields := []*Table{field}
+	for _, stage := range p {
+		var next []*Table
+		for _, f := range fields {
+			next = append(next, stage.Process(f)...)
+		}
+		fields = next
+	}
+	return fields
+}
+
+// Flush Завершает этапы по порядку. Поля, выданные этапом при завершении, проходят через
+// следующие этапы до их собственного завершения
+func (p Pipeline) Flush() []*Table {
+	var fields []*Table
+	for i, stage := range p {
+		for _, f := range stage.Flush() {
+			fields = append(fields, p[i+1:].Process(f)...)
+		}
+	}
+	return fields
+}
+
+// sourceStage Этап, который накапливает поля одного файла и завершает их после чтения файла
+type sourceStage interface {
+	FlushSource(source string) []*Table
+}
+
+// FlushSource Завершает поля файла source в этапах, которые накапливают поля по файлам.
+// Выданные поля проходят через следующие этапы
+func (p Pipeline) FlushSource(source string) []*Table {
+	var fields []*Table
+	for i, stage := range p {
+		if stage, ok := stage.(sourceStage); ok {
+			for _, f := range stage.FlushSource(source) {
+				fields = append(fields, p[i+1:].Process(f)...)
+			}
+		}
+	}
+	return fields
+}
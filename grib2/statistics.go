@@ -0,0 +1,106 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Percentiles Процентили, вычисляемые для каждого поля вместе с остальными характеристиками
+var Percentiles = []float64{5, 25, 50, 75, 95}
+
+// Statistics Характеристики значений поля без учета точек без данных. Стандартное отклонение
+// вычисляется по всем точкам поля (без поправки на число степеней свободы). Процентили записываются
+// по ключам вида "p95" и вычисляются линейной интерполяцией между соседними по порядку значениями
+type Statistics struct {
+	Min         float64
+	Max         float64
+	Mean        float64
+	Std         float64
+	Percentiles map[string]float64 `json:",omitempty"`
+}
+
+// ComputeStatistics Вычисляет характеристики значений data и процентили percentiles. Если в поле
+// нет ни одного значения, возвращает nil
+func ComputeStatistics(data Values, percentiles []float64) *Statistics {
+	count := 0
+	stats := &Statistics{Min: math.Inf(1), Max: math.Inf(-1)}
+	sum := 0.0
+	for _, value := range data {
+		if IsMissing(value) {
+			continue
+		}
+		count++
+		sum += value
+		stats.Min = math.Min(stats.Min, value)
+		stats.Max = math.Max(stats.Max, value)
+	}
+	if count == 0 {
+		return nil
+	}
+	stats.Mean = sum / float64(count)
+	// Отклонения суммируются вторым проходом, чтобы не терять точность на полях с большим средним
+	squares := 0.0
+	for _, value := range data {
+		if !IsMissing(value) {
+			squares += (value - stats.Mean) * (value - stats.Mean)
+		}
+	}
+	stats.Std = math.Sqrt(squares / float64(count))
+	if len(percentiles) == 0 {
+		return stats
+	}
+	sorted := make([]float64, 0, count)
+	for _, value := range data {
+		if !IsMissing(value) {
+			sorted = append(sorted, value)
+		}
+	}
+	sort.Float64s(sorted)
+	stats.Percentiles = make(map[string]float64, len(percentiles))
+	for _, p := range percentiles {
+		rank := p / 100 * float64(count-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		stats.Percentiles[percentileKey(p)] = sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+	}
+	return stats
+}
+
+// percentileKey Возвращает ключ процентиля: "p50", "p2.5"
+func percentileKey(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// ParsePercentiles Разбирает процентили через запятую, например "5,50,95". Пустая строка отключает
+// вычисление процентилей
+func ParsePercentiles(spec string) ([]float64, error) {
+	percentiles := []float64{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		p, err := strconv.ParseFloat(part, 64)
+		if err != nil || math.IsNaN(p) || p < 0 || p > 100 {
+			return nil, fmt.Errorf("процентиль %q: ожидается число от 0 до 100", part)
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
+}
+
+// statisticsValues Возвращает значения колонок value_min, value_max, value_mean, value_std
+// и value_percentiles. Для поля без значений характеристики записываются как NULL
+func statisticsValues(stats *Statistics) []interface{} {
+	if stats == nil {
+		return []interface{}{nil, nil, nil, nil, map[string]float64{}}
+	}
+	percentiles := stats.Percentiles
+	if percentiles == nil {
+		percentiles = map[string]float64{}
+	}
+	return []interface{}{stats.Min, stats.Max, stats.Mean, stats.Std, percentiles}
+}
@@ -0,0 +1,107 @@
+package grib2
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestComputeStatistics(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        Values
+		percentiles []float64
+		want        *Statistics
+	}{
+		{
+			"пропуски не учитываются",
+			Values{1, 2, MissingValue, 3, 4},
+			[]float64{0, 25, 50, 2.5, 100},
+			&Statistics{Min: 1, Max: 4, Mean: 2.5, Std: math.Sqrt(1.25), Percentiles: map[string]float64{"p0": 1, "p25": 1.75, "p50": 2.5, "p2.5": 1.075, "p100": 4}},
+		},
+		{
+			"одно значение",
+			Values{MissingValue, 7},
+			[]float64{5, 95},
+			&Statistics{Min: 7, Max: 7, Mean: 7, Std: 0, Percentiles: map[string]float64{"p5": 7, "p95": 7}},
+		},
+		{
+			"без процентилей",
+			Values{-1, 1},
+			nil,
+			&Statistics{Min: -1, Max: 1, Mean: 0, Std: 1},
+		},
+		{"все значения пропущены", Values{MissingValue, MissingValue}, []float64{50}, nil},
+		{"пустое поле", Values{}, []float64{50}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ComputeStatistics(test.data, test.percentiles)
+			if (got == nil) != (test.want == nil) {
+				t.Fatalf("характеристики %+v, ожидались %+v", got, test.want)
+			}
+			if got == nil {
+				return
+			}
+			same := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+			if !same(got.Min, test.want.Min) || !same(got.Max, test.want.Max) || !same(got.Mean, test.want.Mean) || !same(got.Std, test.want.Std) {
+				t.Fatalf("характеристики %+v, ожидались %+v", got, test.want)
+			}
+			if len(got.Percentiles) != len(test.want.Percentiles) {
+				t.Fatalf("процентили %v, ожидались %v", got.Percentiles, test.want.Percentiles)
+			}
+			for key, value := range test.want.Percentiles {
+				if !same(got.Percentiles[key], value) {
+					t.Fatalf("процентили %v, ожидались %v", got.Percentiles, test.want.Percentiles)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePercentiles(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []float64
+		ok   bool
+	}{
+		{"5,25,50,75,95", []float64{5, 25, 50, 75, 95}, true},
+		{" 2.5 , 97.5 ,", []float64{2.5, 97.5}, true},
+		{"", []float64{}, true},
+		{"101", nil, false},
+		{"-1", nil, false},
+		{"NaN", nil, false},
+		{"p50", nil, false},
+	}
+	for _, test := range tests {
+		got, err := ParsePercentiles(test.spec)
+		if (err == nil) != test.ok || test.ok && !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("%q: %v, %v, ожидалось %v", test.spec, got, err, test.want)
+		}
+	}
+}
+
+func TestStatisticsValues(t *testing.T) {
+	if values := statisticsValues(nil); values[0] != nil || !reflect.DeepEqual(values[4], map[string]float64{}) {
+		t.Fatalf("колонки поля без значений %v", values)
+	}
+	stats := &Statistics{Min: 1, Max: 2, Mean: 1.5, Std: 0.5}
+	want := []interface{}{1.0, 2.0, 1.5, 0.5, map[string]float64{}}
+	if values := statisticsValues(stats); !reflect.DeepEqual(values, want) {
+		t.Fatalf("колонки %v, ожидались %v", values, want)
+	}
+}
+
+func TestFieldStatistics(t *testing.T) {
+	field := testField{ni: 2, nj: 2, codes: []uint64{0, 10, 20, 30}}.table(t)
+	if field.Statistics == nil || field.Statistics.Min != 0 || field.Statistics.Max != 30 || field.Statistics.Mean != 15 {
+		t.Fatalf("характеристики прочитанного поля %+v", field.Statistics)
+	}
+	// Характеристики поля с новыми значениями вычисляются заново
+	if derived := field.derive(Values{MissingValue, 5, MissingValue, 5}); derived.Statistics == nil || derived.Statistics.Min != 5 || derived.Statistics.Std != 0 {
+		t.Fatalf("характеристики производного поля %+v", derived.Statistics)
+	}
+	if derived := field.derive(Values{MissingValue, MissingValue, MissingValue, MissingValue}); derived.Statistics != nil {
+		t.Fatalf("у поля без значений есть характеристики %+v", derived.Statistics)
+	}
+}
@@ -0,0 +1,348 @@
+package grib2
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gribV2.com/config"
+)
+
+// ErrSeriesOrder Поле ряда пришло раньше уже прочитанного шага или накоплено от неизвестного начала
+var ErrSeriesOrder = errors.New("поле не продолжает временной ряд")
+
+// seriesKey Временной ряд: параметр, уровень, сетка и участник ансамбля одного срока прогноза
+type seriesKey struct {
+	date                      int64
+	parameter                 parameterKey
+	surfaceType, surfaceValue string
+	grid                      interface{}
+	points                    int
+	process                   uint8
+	ensembleType, ensemble    uint8
+}
+
+// timeSeries Состояние ряда: последнее поле и для накоплений суммы от начала прогноза к концу
+// прочитанных интервалов
+type timeSeries struct {
+	mu     sync.Mutex
+	last   *Table
+	totals map[int64]Values
+}
+
+// TimeSeries Собирает поля одного параметра и уровня по шагам прогноза и дополняет их полями
+// на промежуточных сроках с шагом Step, отсчитанным от начала прогноза. Поля должны поступать
+// по возрастанию конца интервала
+type TimeSeries struct {
+	Step   time.Duration
+	series timeSeries
+}
+
+// NewTimeSeries Создает ряд с шагом step, кратным часу
+func NewTimeSeries(step time.Duration) (*TimeSeries, error) {
+	if step <= 0 || step%time.Hour != 0 {
+		return nil, fmt.Errorf("шаг %s должен быть положительным и кратным часу", step)
+	}
+	return &TimeSeries{Step: step}, nil
+}
+
+// Add Добавляет поле ряда и возвращает поля на промежуточных сроках между ним и предыдущим полем.
+// Мгновенные поля интерполируются линейно по времени. Накопления переводятся в суммы от начала
+// прогноза, прирост за интервал распределяется по промежуточным срокам равномерно, после чего суммы
+// снова отсчитываются от начала интервала поля. Синтетические поля отмечены Interpolated
+func (s *TimeSeries) Add(field *Table) ([]*Table, error) {
+	return s.series.add(field, s.Step)
+}
+
+// InterpolateTime Дополняет поля одного ряда промежуточными сроками с шагом step и возвращает
+// весь ряд по возрастанию конца интервала. Исходные поля не изменяются
+func InterpolateTime(fields []*Table, step time.Duration) ([]*Table, error) {
+	series, err := NewTimeSeries(step)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]*Table(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].WindowEnd.Before(sorted[j].WindowEnd) })
+	var result []*Table
+	for _, field := range sorted {
+		if !sameSeries(sorted[0], field) {
+			return nil, fmt.Errorf("поля %s и %s относятся к разным рядам", sorted[0].Parameter.ShortName, field.Parameter.ShortName)
+		}
+		synthetic, err := series.Add(field)
+		if err != nil {
+			return nil, err
+		}
+		result = append(append(result, synthetic...), field)
+	}
+	return result, nil
+}
+
+// newSeriesKey Возвращает ряд поля
+func newSeriesKey(field *Table) seriesKey {
+	grid, _ := gridKey(field.Section3.Sec3.Definition)
+	return seriesKey{
+		date:         field.Date.Unix(),
+		parameter:    fieldKey(field),
+		surfaceType:  field.SurfaceType,
+		surfaceValue: field.SurfaceValue,
+		grid:         grid,
+		points:       len(field.Data),
+		process:      field.StatisticalProcess,
+		ensembleType: field.EnsembleType,
+		ensemble:     field.EnsembleMember,
+	}
+}
+
+// sameSeries Сообщает, что поля относятся к одному ряду
+func sameSeries(a, b *Table) bool {
+	return newSeriesKey(a) == newSeriesKey(b)
+}
+
+// add Добавляет поле в ряд и возвращает синтетические поля до него
+func (s *timeSeries) add(field *Table, step time.Duration) ([]*Table, error) {
+	// Промежуточные сроки должны выражаться целым числом единиц времени прогноза поля
+	if unit, ok := timeUnitDuration(field.timeUnit); !ok || step%unit != 0 {
+		return nil, fmt.Errorf("шаг %s не кратен единице времени прогноза %s (%d)", step, field.Parameter.ShortName, field.timeUnit)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch field.StatisticalProcess {
+	case ProcessNone:
+		return s.addInstant(field, step)
+	case ProcessAccumulation:
+		return s.addAccumulation(field, step)
+	}
+	return nil, fmt.Errorf("поля с типом обработки %d не интерполируются по времени", field.StatisticalProcess)
+}
+
+// between Возвращает сроки с шагом step от начала прогноза date строго между from и to
+func between(date, from, to time.Time, step time.Duration) []time.Time {
+	var times []time.Time
+	next := date.Add((from.Sub(date)/step + 1) * step)
+	for ; next.Before(to); next = next.Add(step) {
+		if next.After(from) {
+			times = append(times, next)
+		}
+	}
+	return times
+}
+
+// addInstant Интерполирует мгновенные поля между последним и новым сроком
+func (s *timeSeries) addInstant(field *Table, step time.Duration) ([]*Table, error) {
+	last := s.last
+	if last != nil && field.WindowEnd.Before(last.WindowEnd) {
+		return nil, fmt.Errorf("%w: %s на %s после %s", ErrSeriesOrder, field.Parameter.ShortName, field.WindowEnd, last.WindowEnd)
+	}
+	s.last = field.kept()
+	if last == nil || field.WindowEnd.Equal(last.WindowEnd) {
+		return nil, nil
+	}
+	span := float64(field.WindowEnd.Sub(last.WindowEnd))
+	var fields []*Table
+	for _, valid := range between(field.Date, last.WindowEnd, field.WindowEnd, step) {
+		weight := float64(valid.Sub(last.WindowEnd)) / span
+		data := make(Values, len(field.Data))
+		for i := range data {
+			if IsMissing(last.Data[i]) || IsMissing(field.Data[i]) {
+				data[i] = MissingValue
+				continue
+			}
+			data[i] = last.Data[i] + (field.Data[i]-last.Data[i])*weight
+		}
+		interpolated, err := synthetic(field, data, valid, valid)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, interpolated)
+	}
+	return fields, nil
+}
+
+// addAccumulation Распределяет накопление между последним и новым концом интервала. Суммы от начала
+// прогноза хранятся к концам интервалов не раньше начала интервала нового поля
+func (s *timeSeries) addAccumulation(field *Table, step time.Duration) ([]*Table, error) {
+	if s.totals == nil {
+		s.totals = map[int64]Values{}
+	}
+	base, ok := s.totals[field.WindowStart.Unix()]
+	if !ok && !field.WindowStart.Equal(field.Date) {
+		return nil, fmt.Errorf("%w: %s накоплено от %s, сумма к этому сроку неизвестна", ErrSeriesOrder, field.Parameter.ShortName, field.WindowStart)
+	}
+	previousEnd, previous := field.Date, Values(nil)
+	if s.last != nil {
+		if field.WindowEnd.Before(s.last.WindowEnd) {
+			return nil, fmt.Errorf("%w: %s на %s после %s", ErrSeriesOrder, field.Parameter.ShortName, field.WindowEnd, s.last.WindowEnd)
+		}
+		previousEnd = s.last.WindowEnd
+		if previous, ok = s.totals[previousEnd.Unix()]; !ok {
+			return nil, fmt.Errorf("%w: %s, сумма к %s неизвестна", ErrSeriesOrder, field.Parameter.ShortName, previousEnd)
+		}
+	}
+	total := make(Values, len(field.Data))
+	for i, value := range field.Data {
+		total[i] = value
+		if base != nil && !IsMissing(value) {
+			if IsMissing(base[i]) {
+				total[i] = MissingValue
+				continue
+			}
+			total[i] += base[i]
+		}
+	}
+	s.last = field.kept()
+	for end := range s.totals {
+		if end < field.WindowStart.Unix() {
+			delete(s.totals, end)
+		}
+	}
+	s.totals[field.WindowEnd.Unix()] = total
+	if !field.WindowEnd.After(previousEnd) {
+		return nil, nil
+	}
+	span := float64(field.WindowEnd.Sub(previousEnd))
+	var fields []*Table
+	for _, end := range between(field.Date, previousEnd, field.WindowEnd, step) {
+		weight := float64(end.Sub(previousEnd)) / span
+		data := make(Values, len(total))
+		for i := range data {
+			from := 0.0
+			if previous != nil {
+				from = previous[i]
+			}
+			if IsMissing(total[i]) || IsMissing(from) {
+				data[i] = MissingValue
+				continue
+			}
+			value := from + (total[i]-from)*weight
+			if base != nil {
+				value -= base[i]
+			}
+			data[i] = value
+		}
+		interpolated, err := synthetic(field, data, field.WindowStart, end)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, interpolated)
+	}
+	return fields, nil
+}
+
+// kept Возвращает поле для хранения в ряду без упакованных кодов
+func (t *Table) kept() *Table {
+	field := *t
+	field.Data_int = nil
+	return &field
+}
+
+// synthetic Создает поле ряда на промежуточном сроке с интервалом start-end. Время прогноза
+// указывает на начало интервала в единицах поля field, как у полей grib2.Deaccumulate
+func synthetic(field *Table, data Values, start, end time.Time) (*Table, error) {
+	step, err := field.step(start)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field.Parameter.ShortName, err)
+	}
+	interpolated := field.derive(data)
+	interpolated.WindowStart = start
+	interpolated.WindowEnd = end
+	interpolated.ForecastTime = step
+	interpolated.Interpolated = true
+	return interpolated, nil
+}
+
+// TimeStage Этап интерполяции по времени. Мгновенные поля и накопления параметров Parameters
+// (все параметры, если список пуст) собираются в ряды по параметру, уровню, сетке и участнику
+// ансамбля одного срока прогноза. Перед каждым полем ряда передаются поля на промежуточных сроках
+// с шагом Step, синтетические поля отмечены Interpolated. Поля, которые не продолжают ряд,
+// проходят без изменений, а предупреждение записывается в лог. Ряды более ранних сроков прогноза
+// отбрасываются, как только приходит поле более позднего срока
+type TimeStage struct {
+	Step       time.Duration
+	Parameters []string
+
+	mu     sync.Mutex
+	series map[seriesKey]*timeSeries
+	latest time.Time // Самый поздний срок прогноза среди полученных полей
+}
+
+// ParseTimeInterpolation Создает этап по шагу step в записи time.ParseDuration, например "1h",
+// и списку коротких названий параметров через запятую
+func ParseTimeInterpolation(step string, parameters string) (*TimeStage, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(step))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := NewTimeSeries(duration); err != nil {
+		return nil, err
+	}
+	stage := &TimeStage{Step: duration}
+	for _, name := range strings.Split(parameters, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			stage.Parameters = append(stage.Parameters, name)
+		}
+	}
+	return stage, nil
+}
+
+// accepts Сообщает, что поле интерполируется этапом
+func (s *TimeStage) accepts(field *Table) bool {
+	if field.Interpolated || field.Parameter.Categorical() || field.EnsembleProduct != "" {
+		return false
+	}
+	if field.StatisticalProcess != ProcessNone && field.StatisticalProcess != ProcessAccumulation {
+		return false
+	}
+	if len(s.Parameters) == 0 {
+		return true
+	}
+	for _, name := range s.Parameters {
+		if name == field.Parameter.ShortName {
+			return true
+		}
+	}
+	return false
+}
+
+// Process Передает поля ряда на промежуточных сроках и само поле
+func (s *TimeStage) Process(field *Table) []*Table {
+	if !s.accepts(field) {
+		return []*Table{field}
+	}
+	key := newSeriesKey(field)
+	s.mu.Lock()
+	if s.series == nil {
+		s.series = map[seriesKey]*timeSeries{}
+	}
+	if field.Date.After(s.latest) {
+		s.latest = field.Date
+		for previous := range s.series {
+			if previous.date < key.date {
+				delete(s.series, previous)
+			}
+		}
+	}
+	series, ok := s.series[key]
+	if !ok {
+		series = &timeSeries{}
+		s.series[key] = series
+	}
+	s.mu.Unlock()
+	fields, err := series.add(field, s.Step)
+	if err != nil {
+		config.Logger.WithError(err).WithField("parameter", field.Parameter.ShortName).WithField("level", field.SurfaceType+" "+field.SurfaceValue).Warn("Поле не интерполируется по времени")
+		return []*Table{field}
+	}
+	return append(fields, field)
+}
+
+// Flush Этап не задерживает поля, ряды завершаются
+func (s *TimeStage) Flush() []*Table {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.series = nil
+	s.latest = time.Time{}
+	return nil
+}
@@ -0,0 +1,204 @@
+package grib2
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// seriesField Возвращает поле с обработкой process за интервал start-end часов от начала прогноза
+// date и значением value в обеих точках сетки 2x1
+func seriesField(t *testing.T, date time.Time, process uint8, start, end int, value float64) *Table {
+	t.Helper()
+	field := testField{ni: 2, nj: 1, data: Values{value, value}}.table(t)
+	field.Date = date
+	field.StatisticalProcess = process
+	field.WindowStart = date.Add(time.Duration(start) * time.Hour)
+	field.WindowEnd = date.Add(time.Duration(end) * time.Hour)
+	field.ForecastTime = int32(start)
+	return field
+}
+
+// seriesStep Интервал и значение поля ряда
+type seriesStep struct {
+	start, end   int
+	value        float64
+	interpolated bool
+}
+
+func TestTimeSeries(t *testing.T) {
+	date := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		process uint8
+		fields  []seriesStep
+		want    []seriesStep
+		err     error
+	}{
+		{
+			"мгновенные поля",
+			ProcessNone,
+			[]seriesStep{{0, 0, 0, false}, {3, 3, 30, false}, {6, 6, 0, false}},
+			[]seriesStep{{0, 0, 0, false}, {1, 1, 10, true}, {2, 2, 20, true}, {3, 3, 30, false}, {4, 4, 20, true}, {5, 5, 10, true}, {6, 6, 0, false}},
+			nil,
+		},
+		{
+			"повтор шага",
+			ProcessNone,
+			[]seriesStep{{0, 0, 0, false}, {0, 0, 5, false}, {2, 2, 7, false}},
+			[]seriesStep{{0, 0, 0, false}, {0, 0, 5, false}, {1, 1, 6, true}, {2, 2, 7, false}},
+			nil,
+		},
+		{
+			"накопления от начала прогноза и от шага 6",
+			ProcessAccumulation,
+			[]seriesStep{{0, 3, 3, false}, {0, 6, 9, false}, {6, 9, 3, false}, {6, 12, 12, false}},
+			[]seriesStep{
+				{0, 1, 1, true}, {0, 2, 2, true}, {0, 3, 3, false}, {0, 4, 5, true}, {0, 5, 7, true}, {0, 6, 9, false},
+				{6, 7, 1, true}, {6, 8, 2, true}, {6, 9, 3, false}, {6, 10, 6, true}, {6, 11, 9, true}, {6, 12, 12, false},
+			},
+			nil,
+		},
+		{
+			"мгновенное поле раньше прочитанного",
+			ProcessNone,
+			[]seriesStep{{6, 6, 0, false}, {3, 3, 0, false}},
+			[]seriesStep{{6, 6, 0, false}},
+			ErrSeriesOrder,
+		},
+		{
+			"накопление от неизвестного начала",
+			ProcessAccumulation,
+			[]seriesStep{{6, 9, 3, false}},
+			nil,
+			ErrSeriesOrder,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			series, err := NewTimeSeries(time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []*Table
+			for _, step := range test.fields {
+				field := seriesField(t, date, test.process, step.start, step.end, step.value)
+				var synthetic []*Table
+				if synthetic, err = series.Add(field); err != nil {
+					break
+				}
+				got = append(append(got, synthetic...), field)
+			}
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("получено %d полей, ожидалось %d", len(got), len(test.want))
+			}
+			for k, want := range test.want {
+				field := got[k]
+				start, end := int(field.WindowStart.Sub(date)/time.Hour), int(field.WindowEnd.Sub(date)/time.Hour)
+				if start != want.start || end != want.end || field.Interpolated != want.interpolated || field.ForecastTime != int32(want.start) {
+					t.Fatalf("поле %d: %d-%d (время прогноза %d, interpolated %v), ожидалось %d-%d", k, start, end, field.ForecastTime, field.Interpolated, want.start, want.end)
+				}
+				for _, value := range field.Data {
+					if math.Abs(value-want.value) > 1e-9 {
+						t.Fatalf("поле %d-%d: значения %v, ожидалось %g", start, end, field.Data, want.value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTimeSeriesLostTotal(t *testing.T) {
+	date := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	series, err := NewTimeSeries(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := series.Add(seriesField(t, date, ProcessAccumulation, 0, 3, 3)); err != nil {
+		t.Fatal(err)
+	}
+	// Без суммы к концу последнего интервала накопление не распределяется от нуля
+	delete(series.series.totals, date.Add(3*time.Hour).Unix())
+	fields, err := series.Add(seriesField(t, date, ProcessAccumulation, 0, 6, 9))
+	if !errors.Is(err, ErrSeriesOrder) || len(fields) != 0 {
+		t.Fatalf("ошибка %v и %d полей, ожидалась %v", err, len(fields), ErrSeriesOrder)
+	}
+}
+
+func TestTimeStageDates(t *testing.T) {
+	first := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	second := first.Add(6 * time.Hour)
+	stage := &TimeStage{Step: time.Hour}
+	process := func(field *Table) int {
+		t.Helper()
+		fields := stage.Process(field)
+		if fields[len(fields)-1] != field {
+			t.Fatal("поле не передано после промежуточных")
+		}
+		return len(fields) - 1
+	}
+	if count := process(seriesField(t, first, ProcessNone, 0, 0, 0)); count != 0 {
+		t.Fatalf("перед первым полем ряда добавлено %d полей", count)
+	}
+	if count := process(seriesField(t, first, ProcessNone, 3, 3, 3)); count != 2 {
+		t.Fatalf("между шагами 0 и 3 добавлено %d полей, ожидалось 2", count)
+	}
+	// Поле следующего срока прогноза отбрасывает ряды прежнего срока
+	if count := process(seriesField(t, second, ProcessNone, 0, 0, 0)); count != 0 {
+		t.Fatalf("перед первым полем нового срока добавлено %d полей", count)
+	}
+	if len(stage.series) != 1 {
+		t.Fatalf("хранится %d рядов, ожидался 1", len(stage.series))
+	}
+	// Поле прежнего срока начинает ряд заново
+	if count := process(seriesField(t, first, ProcessNone, 6, 6, 6)); count != 0 {
+		t.Fatalf("перед полем отброшенного ряда добавлено %d полей", count)
+	}
+	if count := process(seriesField(t, second, ProcessNone, 2, 2, 2)); count != 1 {
+		t.Fatalf("между шагами 0 и 2 нового срока добавлено %d полей, ожидалось 1", count)
+	}
+	stage.Flush()
+	if stage.series != nil {
+		t.Fatal("ряды не освобождены после завершения")
+	}
+}
+
+func TestTimeSeriesUnits(t *testing.T) {
+	date := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		unit     uint8
+		forecast int32 // Время прогноза промежуточного поля на шаге 1 ч
+		err      bool
+	}{
+		{"в часах", 1, 1, false},
+		{"в минутах", 0, 60, false},
+		{"в шестичасовых единицах", 11, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			series, err := NewTimeSeries(time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var synthetic []*Table
+			for _, step := range []int{0, 2} {
+				field := seriesField(t, date, ProcessNone, step, step, 0)
+				field.timeUnit = test.unit
+				if synthetic, err = series.Add(field); err != nil {
+					break
+				}
+			}
+			if (err != nil) != test.err {
+				t.Fatalf("ошибка %v, ожидалась %v", err, test.err)
+			}
+			if err == nil && (len(synthetic) != 1 || synthetic[0].ForecastTime != test.forecast) {
+				t.Fatalf("промежуточные поля %+v, ожидалось одно с временем прогноза %d", synthetic, test.forecast)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// UnitConversion Пересчет значений из единиц From в единицы To: value*Scale + Offset
+type UnitConversion struct {
+	From   string
+	To     string
+	Scale  float64
+	Offset float64
+}
+
+// Apply Пересчитывает значение
+func (c UnitConversion) Apply(value float64) float64 {
+	return value*c.Scale + c.Offset
+}
+
+// inverse Возвращает обратный пересчет
+func (c UnitConversion) inverse() UnitConversion {
+	return UnitConversion{From: c.To, To: c.From, Scale: 1 / c.Scale, Offset: -c.Offset / c.Scale}
+}
+
+// unitKey Пара единиц пересчета в нормализованной записи
+type unitKey struct {
+	from, to string
+}
+
+// normalizeUnit Приводит запись единиц к виду для сравнения: без учета регистра и лишних пробелов,
+// так что "Kg m-2" и "kg  m-2" совпадают
+func normalizeUnit(unit string) string {
+	return strings.ToLower(strings.Join(strings.Fields(unit), " "))
+}
+
+// addUnits Добавляет пересчеты из строк таблицы units с колонками unit, target, scale, offset
+func (s *tableSource) addUnits(rows []map[string]string) error {
+	for i, row := range rows {
+		conversion := UnitConversion{From: row["unit"], To: row["target"]}
+		if normalizeUnit(conversion.From) == "" || normalizeUnit(conversion.To) == "" {
+			return fmt.Errorf("строка %d: не указаны единицы", i+1)
+		}
+		var err error
+		if conversion.Scale, err = strconv.ParseFloat(row["scale"], 64); err != nil || conversion.Scale == 0 {
+			return fmt.Errorf("строка %d: неверное значение scale %q", i+1, row["scale"])
+		}
+		if row["offset"] != "" {
+			if conversion.Offset, err = strconv.ParseFloat(row["offset"], 64); err != nil {
+				return fmt.Errorf("строка %d: неверное значение offset %q", i+1, row["offset"])
+			}
+		}
+		s.units[unitKey{normalizeUnit(conversion.From), normalizeUnit(conversion.To)}] = conversion
+	}
+	return nil
+}
+
+// Conversion Возвращает пересчет из единиц from в единицы to. Пересчет ищется в таблицах units
+// от последнего загруженного источника к встроенному, обратный пересчет получается из прямого
+func (r *TableRegistry) Conversion(from string, to string) (UnitConversion, bool) {
+	key := unitKey{normalizeUnit(from), normalizeUnit(to)}
+	if key.from == key.to {
+		return UnitConversion{From: from, To: from, Scale: 1}, true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.sources) - 1; i >= 0; i-- {
+		if conversion, ok := r.sources[i].units[key]; ok {
+			return conversion, true
+		}
+		if conversion, ok := r.sources[i].units[unitKey{key.to, key.from}]; ok {
+			return conversion.inverse(), true
+		}
+	}
+	return UnitConversion{}, false
+}
+
+// Convert Возвращает поле, пересчитанное в единицы unit по таблице пересчетов, например
+// t.Convert("°C") для температуры в K. Новые единицы записываются в Parameter.Unit
+func (t *Table) Convert(unit string) (*Table, error) {
+	if t.Parameter.Categorical() {
+		return nil, fmt.Errorf("значения категориального параметра %s не пересчитываются", t.Parameter.ShortName)
+	}
+	conversion, ok := Tables.Conversion(t.Parameter.Unit, unit)
+	if !ok {
+		return nil, fmt.Errorf("нет пересчета %s из %q в %q", t.Parameter.ShortName, t.Parameter.Unit, unit)
+	}
+	if conversion.Scale == 1 && conversion.Offset == 0 && conversion.To == t.Parameter.Unit {
+		return t, nil
+	}
+	round := t.convertRound(conversion.Scale)
+	data := make(Values, len(t.Data))
+	for i, value := range t.Data {
+		if IsMissing(value) {
+			data[i] = value
+			continue
+		}
+		data[i] = round(conversion.Apply(value))
+	}
+	field := t.derive(data)
+	field.UUID = t.UUID
+	field.Parameter.Unit = conversion.To
+	return field, nil
+}
+
+// convertRound Возвращает функцию приведения пересчитанных значений к точности RoundValues
+// и Float32Values. Шаг упаковки 2^E * 10^-D пересчитывается вместе со значениями
+func (t *Table) convertRound(scale float64) func(float64) float64 {
+	factor := 0.0
+	if RoundValues && len(t.Data_int) > 0 {
+		step := math.Pow(2, float64(t.BinaryScale)) * math.Pow(10, -float64(t.DecimalScale)) * math.Abs(scale)
+		factor = math.Pow(10, math.Ceil(-math.Log10(step)))
+	}
+	return func(value float64) float64 {
+		if factor != 0 {
+			value = math.Round(value*factor) / factor
+		}
+		if Float32Values {
+			value = float64(float32(value))
+		}
+		return value
+	}
+}
+
+// UnitStage Этап пересчета полей в заданные единицы. Единицы выбираются по короткому названию
+// параметра (Parameters), а если для параметра они не заданы — по исходным единицам из
+// каталога параметров (Units, ключи в записи normalizeUnit). Поля без пересчета проходят без изменений
+type UnitStage struct {
+	immediate
+	Units      map[string]string
+	Parameters map[string]string
+
+	mu     sync.Mutex
+	warned map[unitKey]bool
+}
+
+// ParseUnits Создает этап по правилам через ";": "K:°C" пересчитывает все поля в K в °C,
+// "HGT=dam" — поля параметра HGT в dam. Пересчеты по исходным единицам проверяются сразу
+func ParseUnits(spec string) (*UnitStage, error) {
+	stage := &UnitStage{Units: map[string]string{}, Parameters: map[string]string{}}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if name, unit, ok := strings.Cut(part, "="); ok {
+			if strings.TrimSpace(name) == "" || strings.TrimSpace(unit) == "" {
+				return nil, fmt.Errorf("единицы %q: ожидается параметр=единицы", part)
+			}
+			stage.Parameters[strings.TrimSpace(name)] = strings.TrimSpace(unit)
+			continue
+		}
+		from, to, ok := strings.Cut(part, ":")
+		if !ok || strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			return nil, fmt.Errorf("единицы %q: ожидается исходные:новые единицы", part)
+		}
+		if _, ok := Tables.Conversion(from, to); !ok {
+			return nil, fmt.Errorf("нет пересчета из %q в %q", strings.TrimSpace(from), strings.TrimSpace(to))
+		}
+		stage.Units[normalizeUnit(from)] = strings.TrimSpace(to)
+	}
+	return stage, nil
+}
+
+// Process Пересчитывает поле в заданные единицы. Поле, для которого пересчета нет, проходит
+// без изменений, а предупреждение записывается в лог один раз для пары единиц
+func (s *UnitStage) Process(field *Table) []*Table {
+	unit, ok := s.Parameters[field.Parameter.ShortName]
+	if !ok {
+		unit, ok = s.Units[normalizeUnit(field.Parameter.Unit)]
+	}
+	if !ok || field.Parameter.Categorical() {
+		return []*Table{field}
+	}
+	converted, err := field.Convert(unit)
+	if err != nil {
+		key := unitKey{field.Parameter.ShortName + " " + field.Parameter.Unit, unit}
+		s.mu.Lock()
+		if s.warned == nil {
+			s.warned = map[unitKey]bool{}
+		}
+		warn := !s.warned[key]
+		s.warned[key] = true
+		s.mu.Unlock()
+		if warn {
+			config.Logger.WithError(err).WithField("parameter", field.Parameter.ShortName).Warn("Поле сохраняется в исходных единицах")
+		}
+		return []*Table{field}
+	}
+	return []*Table{converted}
+}
@@ -0,0 +1,155 @@
+package grib2
+
+import (
+	"io/fs"
+	"math"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"gribV2.com/config"
+)
+
+func TestConversion(t *testing.T) {
+	bundled, err := fs.Sub(bundledTables, "tables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Таблица пользователя заменяет пересчет K в °C из встроенной таблицы
+	user := fstest.MapFS{"units.csv": {Data: []byte("unit,target,scale,offset\nK,°C,1,-273\n")}}
+	registry, err := NewTableRegistry(bundled, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		registry    *TableRegistry
+		from, to    string
+		value, want float64
+		ok          bool
+	}{
+		{"прямой пересчет", Tables, "K", "°C", 273.15, 0, true},
+		{"обратный пересчет по строке K,°F", Tables, "°F", "K", 32, 273.15, true},
+		{"обратный пересчет по строке Pa,hPa", Tables, "hPa", "Pa", 1013.25, 101325, true},
+		{"регистр и пробелы", Tables, " Kg  M-2 ", "MM", 2.5, 2.5, true},
+		{"одинаковые единицы", Tables, "m s-1", "M S-1", 7, 7, true},
+		{"нет пересчета", Tables, "K", "m", 0, 0, false},
+		{"таблица пользователя", registry, "K", "°C", 273.15, 0.15, true},
+		{"обратный пересчет по таблице пользователя", registry, "°C", "K", 0, 273, true},
+		{"встроенная строка под таблицей пользователя", registry, "Pa", "hPa", 101325, 1013.25, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conversion, ok := test.registry.Conversion(test.from, test.to)
+			if ok != test.ok {
+				t.Fatalf("пересчет найден %v, ожидалось %v", ok, test.ok)
+			}
+			if value := conversion.Apply(test.value); ok && math.Abs(value-test.want) > 1e-9 {
+				t.Fatalf("%g %s = %g %s, ожидалось %g", test.value, test.from, value, test.to, test.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	defer func(round, float32Values bool) { RoundValues, Float32Values = round, float32Values }(RoundValues, Float32Values)
+	Float32Values = false
+	// Давление с шагом упаковки 0.1 Па: после пересчета в гПа шаг 0.001 гПа
+	pressure := testField{ni: 2, nj: 1, category: 3, number: 0, data: Values{101325.37, MissingValue}}.table(t)
+	pressure.DecimalScale = 1
+	tests := []struct {
+		name  string
+		round bool
+		want  float64
+	}{
+		{"без округления", false, 1013.2537},
+		{"округление до шага упаковки", true, 1013.254},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			RoundValues = test.round
+			converted, err := pressure.Convert("hPa")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(converted.Data[0]-test.want) > 1e-9 || !IsMissing(converted.Data[1]) {
+				t.Fatalf("значения %v, ожидалось %g и пропуск", converted.Data, test.want)
+			}
+			if converted.Parameter.Unit != "hPa" || converted.UUID != pressure.UUID || len(converted.Data_int) != 0 {
+				t.Fatalf("единицы %q, идентификатор изменен %v, %d кодов", converted.Parameter.Unit, converted.UUID != pressure.UUID, len(converted.Data_int))
+			}
+		})
+	}
+	if pressure.Parameter.Unit != "Pa" || pressure.Data[0] != 101325.37 {
+		t.Fatal("изменено исходное поле")
+	}
+
+	rain := testField{ni: 2, nj: 1, category: 1, number: 192}.table(t)
+	if _, err := rain.Convert("%"); err == nil {
+		t.Fatalf("пересчитан категориальный параметр %s", rain.Parameter.ShortName)
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		spec  string
+		valid bool
+	}{
+		{"K:°C; HGT=dam", true},
+		{" ; Pa : hPa ;", true},
+		{"K:m", false},
+		{"=dam", false},
+		{"HGT=", false},
+		{"K", false},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			if _, err := ParseUnits(test.spec); (err == nil) != test.valid {
+				t.Fatalf("ошибка %v, ожидалась корректность %v", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestUnitStage(t *testing.T) {
+	stage, err := ParseUnits("k:°C;PRES=mmHg;TMP=m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := test.NewLocal(config.Logger)
+	defer config.Logger.ReplaceHooks(logrus.LevelHooks{})
+
+	temperature := testField{ni: 2, nj: 1, data: Values{273.15, 283.15}}.table(t)
+	dewpoint := testField{ni: 2, nj: 1, number: 6, data: Values{263.15, 273.15}}.table(t)
+	pressure := testField{ni: 2, nj: 1, category: 3, number: 0, data: Values{100000, 0}}.table(t)
+	rain := testField{ni: 2, nj: 1, category: 1, number: 192}.table(t)
+	tests := []struct {
+		name  string
+		field *Table
+		unit  string
+		value float64
+	}{
+		// Правило параметра важнее правила по исходным единицам, пересчета K в m нет
+		{"температура без пересчета", temperature, "K", 273.15},
+		{"повтор без пересчета", temperature, "K", 273.15},
+		{"по исходным единицам", dewpoint, "°C", -10},
+		{"по параметру", pressure, "mmHg", 750.0615758456564},
+		{"категориальный параметр", rain, rain.Parameter.Unit, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := stage.Process(test.field)
+			if len(fields) != 1 {
+				t.Fatalf("передано %d полей", len(fields))
+			}
+			if field := fields[0]; field.Parameter.Unit != test.unit || math.Abs(field.Data[0]-test.value) > 1e-9 {
+				t.Fatalf("%g %s, ожидалось %g %s", field.Data[0], field.Parameter.Unit, test.value, test.unit)
+			}
+		})
+	}
+	// Предупреждение о пересчете TMP в m записывается один раз
+	if entries := hook.AllEntries(); len(entries) != 1 {
+		t.Fatalf("%d предупреждений, ожидалось 1", len(entries))
+	}
+}
@@ -0,0 +1,258 @@
+package grib2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Severity Уровень серьезности нарушения структуры GRIB2
+type Severity int
+
+const (
+	// SeverityWarning Отклонение от стандарта, при котором данные все еще можно прочитать
+	SeverityWarning Severity = iota
+	// SeverityError Нарушение, при котором данные сообщения нельзя считать достоверными
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprint("unknown ", int(s))
+	}
+}
+
+// MarshalText Записывает уровень в json-отчет словом "warning" или "error"
+func (s Severity) MarshalText() ([]byte, error) {
+	switch s {
+	case SeverityWarning, SeverityError:
+		return []byte(s.String()), nil
+	}
+	return nil, fmt.Errorf("неизвестный уровень нарушения %d", int(s))
+}
+
+// UnmarshalText Читает уровень из json-отчета
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "warning":
+		*s = SeverityWarning
+	case "error":
+		*s = SeverityError
+	default:
+		return fmt.Errorf("неизвестный уровень нарушения %q", text)
+	}
+	return nil
+}
+
+// Violation Нарушение структуры, найденное в сообщении
+type Violation struct {
+	Message  int      `json:"message"` // Порядковый номер сообщения в файле, начиная с 0
+	Section  int      `json:"section"` // Номер секции, -1 если нарушение относится ко всему сообщению
+	Offset   int64    `json:"offset"`  // Смещение в байтах от начала файла
+	Severity Severity `json:"severity"`
+	Text     string   `json:"text"`
+	Panic    bool     `json:"panic,omitempty"` // Декодер аварийно завершился на этом сообщении
+}
+
+func (v Violation) String() string {
+	place := fmt.Sprintf("сообщение %d, смещение %d", v.Message, v.Offset)
+	if v.Section >= 0 {
+		place += fmt.Sprintf(", секция %d", v.Section)
+	}
+	return fmt.Sprintf("[%s] %s: %s", v.Severity, place, v.Text)
+}
+
+// Report Результат проверки файла
+type Report struct {
+	File       string      `json:"file"`
+	Messages   int         `json:"messages"`
+	Violations []Violation `json:"violations"`
+}
+
+// HasErrors Сообщает, есть ли в отчете нарушения уровня SeverityError
+func (r *Report) HasErrors() bool {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPanics Сообщает, завершался ли декодер аварийно хотя бы на одном сообщении
+func (r *Report) HasPanics() bool {
+	for _, v := range r.Violations {
+		if v.Panic {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) add(message int, section int, offset int64, severity Severity, format string, args ...interface{}) {
+	r.Violations = append(r.Violations, Violation{
+		Message:  message,
+		Section:  section,
+		Offset:   offset,
+		Severity: severity,
+		Text:     fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate Проверяет структуру всех сообщений файла и возвращает найденные нарушения.
+// В отличие от парсера проверка не останавливается на первом поврежденном сообщении.
+// Ошибка возвращается только при ошибке чтения самого файла
+func Validate(file io.Reader, name string) (*Report, error) {
+	report := &Report{File: name}
+	scanner := newMessageScanner(file, name)
+	for {
+		raw, err := scanner.Next()
+		if err == io.EOF {
+			return report, nil
+		}
+		var msgErr *MessageError
+		if err != nil {
+			if !errors.As(err, &msgErr) {
+				return report, err
+			}
+			report.Messages++
+			report.add(msgErr.Message, msgErr.Section, msgErr.Offset, SeverityError, "%s", msgErr.Err.Error())
+			scanner.Resync()
+			continue
+		}
+		report.Messages++
+		validateMessage(report, raw, name)
+	}
+}
+
+// validateMessage Проверяет длины секций и количество значений одного сообщения
+func validateMessage(report *Report, raw *rawMessage, name string) {
+	// Обход заголовков секций без их разбора
+	var sum int64 = 16
+	offset := raw.Offset + 16
+	sections := map[uint8]int{}
+	last := uint8(0)
+	body := raw.Body
+	for len(body) > 0 {
+		if len(body) >= 4 && string(body[:4]) == "7777" {
+			sum += 4
+			if len(body) != 4 {
+				report.add(raw.Index, 8, offset, SeverityError, "маркер \"7777\" найден за %d байт до конца сообщения", len(body)-4)
+			}
+			break
+		}
+		if len(body) < 5 {
+			report.add(raw.Index, -1, offset, SeverityError, "неполный заголовок секции")
+			return
+		}
+		length := binary.BigEndian.Uint32(body[:4])
+		number := body[4]
+		if length < 5 || int64(length) > int64(len(body)) {
+			report.add(raw.Index, int(number), offset, SeverityError, "длина секции %d выходит за границы сообщения (осталось %d байт)", length, len(body))
+			return
+		}
+		if number < 1 || number > 7 {
+			report.add(raw.Index, int(number), offset, SeverityError, "неизвестный номер секции")
+		} else if number < last && !(number >= 2 && number <= 4) {
+			// Секции 2-7 могут повторяться, но порядок внутри поля должен сохраняться
+			report.add(raw.Index, int(number), offset, SeverityWarning, "секция %d следует после секции %d", number, last)
+		}
+		sections[number]++
+		last = number
+		sum += int64(length)
+		offset += int64(length)
+		body = body[length:]
+	}
+	if sum != int64(raw.Sec0.MessageLength) {
+		report.add(raw.Index, 0, raw.Offset, SeverityError, "сумма длин секций %d не равна длине сообщения %d", sum, raw.Sec0.MessageLength)
+	}
+	for _, number := range []uint8{1, 3, 4, 5, 6, 7} {
+		if sections[number] == 0 {
+			report.add(raw.Index, int(number), raw.Offset, SeverityError, "секция %d отсутствует", number)
+		}
+	}
+	if sections[3] > 1 || sections[4] > 1 {
+		report.add(raw.Index, -1, raw.Offset, SeverityWarning, "сообщение содержит несколько полей, проверяется только первое")
+	}
+
+	message, err := decodeRaw(raw, name, Rules{})
+	if err != nil {
+		var msgErr *MessageError
+		if errors.As(err, &msgErr) {
+			severity := SeverityError
+			if errors.Is(err, ErrUnsupportedTemplate) {
+				severity = SeverityWarning
+			}
+			report.add(raw.Index, msgErr.Section, msgErr.Offset, severity, "%s", msgErr.Err.Error())
+			report.Violations[len(report.Violations)-1].Panic = errors.Is(err, ErrDecoderPanic)
+		}
+		return
+	}
+	validateCounts(report, raw, message)
+}
+
+// validateCounts Сверяет количество декодированных значений с Секциями 3, 5 и 6
+func validateCounts(report *Report, raw *rawMessage, message *Message) {
+	sec3 := message.Section3
+	sec5 := message.Section5
+	sec6 := message.Section6
+	// С битовой картой значения уже расставлены по всем точкам сетки
+	decoded := len(message.Section7.Data)
+	if sec6.BitmapIndicator == 0 {
+		if uint32(decoded) != sec3.DataPointCount {
+			report.add(raw.Index, 7, raw.Offset, SeverityError, "после применения битовой карты %d значений, в сетке %d точек", decoded, sec3.DataPointCount)
+		}
+	} else if uint32(decoded) != sec5.PointsNumber {
+		report.add(raw.Index, 7, raw.Offset, SeverityError, "декодировано %d значений, в Секции 5 указано %d", decoded, sec5.PointsNumber)
+	}
+	switch sec6.BitmapIndicator {
+	case 0:
+		if uint64(len(sec6.Bitmap))*8 < uint64(sec3.DataPointCount) {
+			report.add(raw.Index, 6, raw.Offset, SeverityError, "битовая карта на %d точек короче сетки из %d точек", len(sec6.Bitmap)*8, sec3.DataPointCount)
+			break
+		}
+		set := uint32(0)
+		for i := uint32(0); i < sec3.DataPointCount; i++ {
+			if sec6.Bitmap[i/8]&(0x80>>(i%8)) != 0 {
+				set++
+			}
+		}
+		if set != sec5.PointsNumber {
+			report.add(raw.Index, 6, raw.Offset, SeverityError, "в битовой карте %d точек, в Секции 5 указано %d", set, sec5.PointsNumber)
+		}
+	case 255:
+		if sec5.PointsNumber != sec3.DataPointCount {
+			report.add(raw.Index, 5, raw.Offset, SeverityError, "без битовой карты количество значений %d должно совпадать с количеством точек сетки %d", sec5.PointsNumber, sec3.DataPointCount)
+		}
+	default:
+		report.add(raw.Index, 6, raw.Offset, SeverityWarning, "битовая карта %d (заранее определенная или из предыдущего поля) не проверяется", sec6.BitmapIndicator)
+	}
+	if points, ok := gridPoints(sec3.Definition); ok && points != uint64(sec3.DataPointCount) {
+		report.add(raw.Index, 3, raw.Offset, SeverityError, "размер сетки %d не равен количеству точек %d", points, sec3.DataPointCount)
+	}
+}
+
+// gridPoints Возвращает количество точек сетки по ее размерам
+func gridPoints(grid interface{}) (uint64, bool) {
+	switch g := grid.(type) {
+	case *Grid0:
+		return uint64(g.Ni) * uint64(g.Nj), true
+	case *Grid10:
+		return uint64(g.Ni) * uint64(g.Nj), true
+	case *Grid20:
+		return uint64(g.Nx) * uint64(g.Ny), true
+	case *Grid30:
+		return uint64(g.Nx) * uint64(g.Ny), true
+	case *Grid40:
+		return uint64(g.Ni) * uint64(g.Nj), true
+	case *Grid90:
+		return uint64(g.Nx) * uint64(g.Ny), true
+	}
+	return 0, false
+}
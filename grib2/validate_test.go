@@ -0,0 +1,58 @@
+package grib2
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateReport(t *testing.T) {
+	good := simpleMessage(3, 2, []uint64{0, 1, 2, 3, 4, 5}, 8, 250, 0, 0, 1)
+	broken := append([]byte(nil), good...)
+	broken[len(broken)-1] = 'X'
+	report, err := Validate(bytes.NewReader(bytes.Join([][]byte{good, broken, good}, nil)), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Messages != 3 || len(report.Violations) != 1 || !report.HasErrors() {
+		t.Fatalf("отчет %+v", report)
+	}
+	if violation := report.Violations[0]; violation.Message != 1 || violation.Severity != SeverityError {
+		t.Fatalf("нарушение %+v", violation)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"severity":"error"`) {
+		t.Fatalf("уровень нарушения не записан словом: %s", data)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Violations[0].Severity != SeverityError {
+		t.Fatalf("отчет не читается обратно: %v %+v", err, decoded)
+	}
+}
+
+func TestSeverityText(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		text     string
+	}{
+		{SeverityWarning, "warning"},
+		{SeverityError, "error"},
+	}
+	for _, test := range tests {
+		text, err := test.severity.MarshalText()
+		if err != nil || string(text) != test.text {
+			t.Fatalf("%d: %q %v", test.severity, text, err)
+		}
+		var severity Severity
+		if err := severity.UnmarshalText(text); err != nil || severity != test.severity {
+			t.Fatalf("%q: %d %v", text, severity, err)
+		}
+	}
+	if _, err := Severity(5).MarshalText(); err == nil {
+		t.Fatal("неизвестный уровень записан без ошибки")
+	}
+}
@@ -0,0 +1,330 @@
+package grib2
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"gribV2.com/config"
+)
+
+// gridRelativeFlag Флаг компонент векторов (Flag table 3.3, бит 5): компоненты u и v заданы
+// относительно осей сетки в направлении возрастания x и y, а не на восток и на север
+const gridRelativeFlag uint8 = 0x08
+
+// VectorPairs Пары параметров — компонент векторов вдоль x и y: ветер, порывы, движение шторма,
+// потоки импульса и влаги, сдвиг ветра, течения, дрейф Стокса и дрейф льда
+var VectorPairs = [][2]parameterKey{
+	{{0, 2, 2}, {0, 2, 3}},
+	{{0, 2, 23}, {0, 2, 24}},
+	{{0, 2, 27}, {0, 2, 28}},
+	{{0, 2, 17}, {0, 2, 18}},
+	{{0, 2, 15}, {0, 2, 16}},
+	{{0, 1, 91}, {0, 1, 92}},
+	{{10, 1, 2}, {10, 1, 3}},
+	{{10, 0, 21}, {10, 0, 22}},
+	{{10, 2, 4}, {10, 2, 5}},
+}
+
+// componentFlags Возвращает флаги разрешения и компонент из определения сетки
+func componentFlags(definition interface{}) (uint8, bool) {
+	switch grid := definition.(type) {
+	case *Grid0:
+		return grid.ResolutionAndComponentFlags, true
+	case *Grid10:
+		return grid.ResolutionAndComponentFlags, true
+	case *Grid20:
+		return grid.ResolutionAndComponentFlags, true
+	case *Grid30:
+		return grid.ResolutionAndComponentFlags, true
+	case *Grid40:
+		return grid.ResolutionAndComponentFlags, true
+	case *Grid90:
+		return grid.ResolutionAndComponentFlags, true
+	}
+	return 0, false
+}
+
+// withComponentFlags Возвращает копию определения сетки с флагами flags
+func withComponentFlags(definition interface{}, flags uint8) interface{} {
+	switch grid := definition.(type) {
+	case *Grid0:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	case *Grid10:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	case *Grid20:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	case *Grid30:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	case *Grid40:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	case *Grid90:
+		copied := *grid
+		copied.ResolutionAndComponentFlags = flags
+		return &copied
+	}
+	return definition
+}
+
+// GridRelative Сообщает, что компоненты векторов на сетке заданы относительно осей сетки
+func GridRelative(section3 Section3) bool {
+	flags, ok := componentFlags(section3.Definition)
+	return ok && flags&gridRelativeFlag != 0
+}
+
+// rotation Направления на восток и на север в осях сетки в каждом узле
+type rotation struct {
+	once           sync.Once
+	eastX, eastY   []float64
+	northX, northY []float64
+	identity       bool
+	err            error
+}
+
+// rotations Кэш направлений по значению определения сетки
+var rotations sync.Map
+
+// rotationStep Шаг по широте и долготе в градусах для вычисления направлений осей
+const rotationStep = 1e-3
+
+// gridRotation Возвращает направления на восток и на север в узлах сетки
+func gridRotation(definition interface{}) (*rotation, error) {
+	key, ok := gridKey(definition)
+	if !ok {
+		return nil, fmt.Errorf("%w: проекция сетки %T", ErrUnsupportedTemplate, definition)
+	}
+	value, _ := rotations.LoadOrStore(key, &rotation{})
+	r := value.(*rotation)
+	r.once.Do(func() {
+		r.err = r.compute(definition)
+	})
+	return r, r.err
+}
+
+// compute Вычисляет направления в узлах по разностям координат на плоскости проекции. На широтно-
+// долготных сетках оси сетки направлены на восток и на север
+func (r *rotation) compute(definition interface{}) error {
+	projection, err := NewProjection(definition)
+	if err != nil {
+		return err
+	}
+	planar, ok := projection.(*planeProjection)
+	if !ok {
+		r.identity = true
+		return nil
+	}
+	ni, nj := planar.Size()
+	r.eastX, r.eastY = make([]float64, ni*nj), make([]float64, ni*nj)
+	r.northX, r.northY = make([]float64, ni*nj), make([]float64, ni*nj)
+	for j := 0; j < nj; j++ {
+		for i := 0; i < ni; i++ {
+			offset := planar.Offset(i, j)
+			lat, lon := planar.LatLon(float64(i), float64(j))
+			if math.IsNaN(lat) {
+				r.eastX[offset] = math.NaN()
+				continue
+			}
+			// На полюсе направление на восток не определено, берется точка рядом с ним
+			lat = math.Max(-90+rotationStep, math.Min(90-rotationStep, lat))
+			xn, yn, okNorth := planar.forward(lat+rotationStep/2, lon)
+			xs, ys, okSouth := planar.forward(lat-rotationStep/2, lon)
+			step := rotationStep / math.Cos(radians(lat))
+			xe, ye, okEast := planar.forward(lat, lon+step/2)
+			xw, yw, okWest := planar.forward(lat, lon-step/2)
+			if !okNorth || !okSouth || !okEast || !okWest {
+				r.eastX[offset] = math.NaN()
+				continue
+			}
+			north := math.Hypot(xn-xs, yn-ys)
+			east := math.Hypot(xe-xw, ye-yw)
+			if north == 0 || east == 0 {
+				r.eastX[offset] = math.NaN()
+				continue
+			}
+			r.eastX[offset], r.eastY[offset] = (xe-xw)/east, (ye-yw)/east
+			r.northX[offset], r.northY[offset] = (xn-xs)/north, (yn-ys)/north
+		}
+	}
+	return nil
+}
+
+// RotateVectors Переводит компоненты векторов u, v вдоль осей сетки section3 в компоненты на восток
+// и на север. Угол между осями сетки и меридианом (схождение меридианов) определяется в каждом узле
+// по проекции. Точки без значения хотя бы одной компоненты остаются пропущенными
+func RotateVectors(section3 Section3, u, v Values) (Values, Values, error) {
+	if len(u) != len(v) {
+		return nil, nil, fmt.Errorf("компоненты имеют разный размер: %d и %d", len(u), len(v))
+	}
+	r, err := gridRotation(section3.Definition)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !r.identity && len(u) != len(r.eastX) {
+		return nil, nil, fmt.Errorf("количество значений %d не совпадает с размером сетки %d", len(u), len(r.eastX))
+	}
+	east, north := make(Values, len(u)), make(Values, len(v))
+	for k := range u {
+		if IsMissing(u[k]) || IsMissing(v[k]) {
+			east[k], north[k] = MissingValue, MissingValue
+			continue
+		}
+		if r.identity {
+			east[k], north[k] = u[k], v[k]
+			continue
+		}
+		if math.IsNaN(r.eastX[k]) {
+			east[k], north[k] = MissingValue, MissingValue
+			continue
+		}
+		// Оси сетки ортонормированы, поэтому компонента на восток — проекция вектора на направление
+		// на восток, записанное в осях сетки
+		east[k] = u[k]*r.eastX[k] + v[k]*r.eastY[k]
+		north[k] = u[k]*r.northX[k] + v[k]*r.northY[k]
+	}
+	return east, north, nil
+}
+
+// RotateToEarth Возвращает компоненты u, v, переведенные от осей сетки на восток и на север,
+// с установленным в описании сетки флагом компонент относительно востока и севера. Поля
+// с компонентами относительно востока и севера возвращаются без изменений
+func RotateToEarth(u, v *Table) (*Table, *Table, error) {
+	if !GridRelative(u.Section3.Sec3) {
+		return u, v, nil
+	}
+	east, north, err := RotateVectors(u.Section3.Sec3, u.Data, v.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	flags, _ := componentFlags(u.Section3.Sec3.Definition)
+	section3 := u.Section3
+	section3.Sec3.Definition = withComponentFlags(section3.Sec3.Definition, flags&^gridRelativeFlag)
+	rotatedU, rotatedV := u.derive(east), v.derive(north)
+	rotatedU.UUID, rotatedV.UUID = u.UUID, v.UUID
+	rotatedU.Section3, rotatedV.Section3 = section3, section3
+	return rotatedU, rotatedV, nil
+}
+
+// vectorKey Компонента вектора: срок, уровень, сетка, участник ансамбля и файл
+type vectorKey struct {
+	source                    string
+	date, start, valid        int64
+	process                   uint8
+	surfaceType, surfaceValue string
+	grid                      interface{}
+	ensembleType, ensemble    uint8
+	product                   string
+	pair                      int
+}
+
+// RotateStage Этап перевода компонент векторов с осей сетки на восток и на север. Компоненты
+// на сетках с флагом компонент относительно осей задерживаются до прочтения парной компоненты
+// того же срока, уровня и сетки, после чего обе поворачиваются и передаются дальше. Компонента
+// без пары передается без изменений после чтения файла
+type RotateStage struct {
+	mu      sync.Mutex
+	pending map[vectorKey][2]*Table
+}
+
+// vectorComponent Возвращает номер пары VectorPairs и компоненту (0 — u, 1 — v)
+func vectorComponent(field *Table) (int, int, bool) {
+	key := fieldKey(field)
+	for pair, components := range VectorPairs {
+		for component, parameter := range components {
+			if parameter == key {
+				return pair, component, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Process Задерживает компоненту на сетке относительно осей до прочтения парной и поворачивает пару
+func (s *RotateStage) Process(field *Table) []*Table {
+	pair, component, ok := vectorComponent(field)
+	if !ok || !GridRelative(field.Section3.Sec3) {
+		return []*Table{field}
+	}
+	grid, _ := gridKey(field.Section3.Sec3.Definition)
+	key := vectorKey{
+		source:       field.source,
+		date:         field.Date.Unix(),
+		start:        field.WindowStart.Unix(),
+		valid:        field.WindowEnd.Unix(),
+		process:      field.StatisticalProcess,
+		surfaceType:  field.SurfaceType,
+		surfaceValue: field.SurfaceValue,
+		grid:         grid,
+		ensembleType: field.EnsembleType,
+		ensemble:     field.EnsembleMember,
+		product:      field.EnsembleProduct,
+		pair:         pair,
+	}
+	s.mu.Lock()
+	if s.pending == nil {
+		s.pending = map[vectorKey][2]*Table{}
+	}
+	components := s.pending[key]
+	components[component] = field
+	if components[0] == nil || components[1] == nil {
+		s.pending[key] = components
+		s.mu.Unlock()
+		return nil
+	}
+	delete(s.pending, key)
+	s.mu.Unlock()
+	u, v, err := RotateToEarth(components[0], components[1])
+	if err != nil {
+		config.Logger.WithError(err).WithField("parameter", components[0].Parameter.ShortName).WithField("level", field.SurfaceType+" "+field.SurfaceValue).Warn("Компоненты не повернуты и сохраняются относительно осей сетки")
+		return components[:]
+	}
+	return []*Table{u, v}
+}
+
+// FlushSource Передает дальше компоненты файла source, для которых не нашлось пары
+func (s *RotateStage) FlushSource(source string) []*Table {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var fields []*Table
+	for key, components := range s.pending {
+		if key.source != source {
+			continue
+		}
+		delete(s.pending, key)
+		fields = append(fields, s.unpaired(components)...)
+	}
+	return fields
+}
+
+// Flush Передает дальше все компоненты без пары
+func (s *RotateStage) Flush() []*Table {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var fields []*Table
+	for _, components := range s.pending {
+		fields = append(fields, s.unpaired(components)...)
+	}
+	s.pending = nil
+	return fields
+}
+
+// unpaired Возвращает компоненту без пары, предупреждение записывается в лог
+func (s *RotateStage) unpaired(components [2]*Table) []*Table {
+	var fields []*Table
+	for _, field := range components {
+		if field != nil {
+			config.Logger.WithField("parameter", field.Parameter.ShortName).WithField("level", field.SurfaceType+" "+field.SurfaceValue).Warn("Нет парной компоненты, компонента сохраняется относительно осей сетки")
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
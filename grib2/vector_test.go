@@ -0,0 +1,96 @@
+package grib2
+
+import (
+	"math"
+	"testing"
+)
+
+// vectorField Возвращает параметр number категории 2 (импульс) на сетке definition со значениями values
+func vectorField(t *testing.T, number uint8, definition interface{}, values []uint64) *Table {
+	t.Helper()
+	return testField{ni: 3, nj: 2, codes: values, category: 2, number: number, definition: definition}.table(t)
+}
+
+func TestRotateVectors(t *testing.T) {
+	// Шаг 500 км, чтобы узлы отстояли от центрального меридиана на несколько градусов
+	grid := lambertGrid(0x38)
+	grid.Dx, grid.Dy = 500000000, 500000000
+	lambert := Section3{Definition: grid}
+	latLon := Section3{Definition: withComponentFlags(regridSource(t).Section3.Sec3.Definition, 0x38)}
+	projection, err := NewProjection(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Для конической проекции Ламберта с одной стандартной параллелью оси сетки повернуты
+	// относительно меридиана на угол sin(latin1) * (lon - lov)
+	cone := math.Sin(radians(50))
+	tests := []struct {
+		name    string
+		section Section3
+		u, v    float64
+	}{
+		{"широтно-долготная сетка", latLon, 3, 4},
+		{"Ламберт, вдоль оси x", lambert, 1, 0},
+		{"Ламберт, вдоль оси y", lambert, 0, 10},
+		{"Ламберт, произвольный вектор", lambert, -3, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, v := make(Values, 6), make(Values, 6)
+			for k := range u {
+				u[k], v[k] = test.u, test.v
+			}
+			east, north, err := RotateVectors(test.section, u, v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k := range east {
+				angle := 0.0
+				if test.section.Definition == grid {
+					lat, lon := projection.LatLon(float64(k%3), float64(k/3))
+					if math.IsNaN(lat) {
+						t.Fatalf("узел %d вне проекции", k)
+					}
+					angle = cone * radians(lon-10)
+					if k == 2 && math.Abs(angle) < 0.05 {
+						t.Fatalf("узел %d слишком близко к центральному меридиану: %g", k, lon)
+					}
+				}
+				wantEast := test.u*math.Cos(angle) + test.v*math.Sin(angle)
+				wantNorth := -test.u*math.Sin(angle) + test.v*math.Cos(angle)
+				if math.Abs(east[k]-wantEast) > 1e-6 || math.Abs(north[k]-wantNorth) > 1e-6 {
+					t.Fatalf("узел %d: %g, %g, ожидалось %g, %g", k, east[k], north[k], wantEast, wantNorth)
+				}
+			}
+		})
+	}
+}
+
+func TestRotateStage(t *testing.T) {
+	u := vectorField(t, 2, lambertGrid(0x38), []uint64{1, 2, 3, 4, 5, 6})
+	v := vectorField(t, 3, lambertGrid(0x38), []uint64{6, 5, 4, 3, 2, 1})
+	stage := &RotateStage{}
+	if fields := stage.Process(u); len(fields) != 0 {
+		t.Fatalf("компонента без пары передана дальше: %d полей", len(fields))
+	}
+	fields := stage.Process(v)
+	if len(fields) != 2 {
+		t.Fatalf("передано %d полей, ожидалась пара", len(fields))
+	}
+	for _, field := range fields {
+		if GridRelative(field.Section3.Sec3) {
+			t.Fatalf("%s: флаг компонент относительно осей сетки не сброшен", field.Parameter.ShortName)
+		}
+		if field.UUID != u.UUID && field.UUID != v.UUID {
+			t.Fatalf("%s: изменился идентификатор поля", field.Parameter.ShortName)
+		}
+	}
+
+	// Компонента без пары сохраняется без поворота с прежним флагом
+	alone := vectorField(t, 2, lambertGrid(0x38), []uint64{1, 2, 3, 4, 5, 6})
+	stage.Process(alone)
+	fields = stage.Flush()
+	if len(fields) != 1 || fields[0] != alone || !GridRelative(fields[0].Section3.Sec3) {
+		t.Fatalf("компонента без пары изменена: %+v", fields)
+	}
+}
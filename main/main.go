@@ -41,6 +41,11 @@ func Parser() {
 	}
 	config.LoggerStart(file)
 	cfg := config.New()
+	// Настройки декодера и этапов обработки проверяются до чтения файлов и подключения к базам данных
+	if err := grib2.Configure(cfg); err != nil {
+		config.Logger.WithError(err).Error("Ошибка в настройках парсера!")
+		os.Exit(1)
+	}
 	config.Logger.Info("Старт парсера...")
 	// Чтение папки с файлами
 	files, err := os.ReadDir(cfg.SrcDir)
@@ -65,7 +70,7 @@ func Parser() {
 		// Запускается основная часть программы, где идет чтение файлов и сохранения указанным способом
 		err:=grib2.Grib_menu(files, cfg)
 		if err!=nil{
-			config.Logger.WithError(err)
+			config.Logger.WithError(err).Error("Ошибка работы парсера!")
 			os.Exit(1)
 		}
 		// Перемещает файлы из исходной папки в папку сохранения
@@ -0,0 +1,83 @@
+// Программа Validate проверяет структуру GRIB2-файлов до их загрузки в базу данных
+//
+// Для каждого сообщения проверяются маркер конца "7777", согласованность длин секций
+// с длиной сообщения из Секции 0 и количество декодированных значений.
+// Использование:
+//
+//	validate [-json] <файл или папка>...
+//
+// Код возврата 1 означает, что найдены нарушения уровня error, 2 — ошибку чтения файлов,
+// 3 — аварийное завершение декодера хотя бы на одном сообщении (ошибка в самом парсере)
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gribV2.com/grib2"
+)
+
+func main() {
+	asJson := flag.Bool("json", false, "вывод отчета в формате json")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Использование: validate [-json] <файл или папка>...")
+		os.Exit(2)
+	}
+	exitCode := 0
+	for _, path := range files(flag.Args()) {
+		report, err := validateFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, path+":", err)
+			exitCode = 2
+			continue
+		}
+		if *asJson {
+			jsonData, _ := json.Marshal(report)
+			fmt.Println(string(jsonData))
+		} else {
+			fmt.Printf("%s: сообщений %d, нарушений %d\n", report.File, report.Messages, len(report.Violations))
+			for _, v := range report.Violations {
+				fmt.Println("  " + v.String())
+			}
+		}
+		if report.HasErrors() && exitCode == 0 {
+			exitCode = 1
+		}
+		if report.HasPanics() {
+			exitCode = 3
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// files Раскрывает папки в список файлов
+func files(paths []string) []string {
+	var result []string
+	for _, path := range paths {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			result = append(result, path)
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				result = append(result, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	return result
+}
+
+// validateFile Проверяет один файл
+func validateFile(path string) (*grib2.Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return grib2.Validate(file, path)
+}